@@ -0,0 +1,364 @@
+package astvalidator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var aggregateFunctions = map[string]bool{
+	AggregateCount:    true,
+	AggregateDistinct: true,
+	AggregateSum:      true,
+	AggregateAvg:      true,
+	AggregateMin:      true,
+	AggregateMax:      true,
+}
+
+func isAggregateFunction(name string) bool {
+	return aggregateFunctions[strings.ToLower(name)]
+}
+
+func parseAggregate(name string) (function, path string, ok bool) {
+	open := strings.IndexByte(name, '(')
+	if open <= 0 || !strings.HasSuffix(name, ")") || !isAggregateFunction(name[:open]) {
+		return "", "", false
+	}
+	return strings.ToLower(name[:open]), name[open+1 : len(name)-1], true
+}
+
+func (e *evaluation) compareAggregate(function, path string, attribute *Attribute) (bool, error) {
+	value, err := e.aggregate(function, path, attribute)
+	if err != nil {
+		return false, err
+	}
+	switch value := value.(type) {
+	case nil:
+		return false, errNullValue
+	case time.Time:
+		conditionValue, err := parseTimeValue(attribute.Value)
+		if err != nil {
+			return false, newParseError(attribute, "time", err)
+		}
+		if attribute.Operator == OperatorEqual {
+			return value.Equal(conditionValue), nil
+		}
+		return validateTime(value, attribute.Operator, conditionValue), nil
+	case int64:
+		if conditionValue, err := strconv.ParseInt(attribute.Value, 10, 64); err == nil {
+			return validateInteger(value, attribute.Operator, conditionValue), nil
+		}
+		conditionValue, err := strconv.ParseFloat(attribute.Value, 64)
+		if err != nil {
+			return false, newParseError(attribute, "number", err)
+		}
+		if attribute.Operator == OperatorEqual {
+			return float64(value) == conditionValue, nil
+		}
+		return validateNumeric(value, attribute.Operator, conditionValue), nil
+	default:
+		conditionValue, err := strconv.ParseFloat(attribute.Value, 64)
+		if err != nil {
+			return false, newParseError(attribute, "number", err)
+		}
+		if attribute.Operator == OperatorEqual {
+			return value == conditionValue, nil
+		}
+		return validateNumeric(value, attribute.Operator, conditionValue), nil
+	}
+}
+
+func (e *evaluation) aggregate(function, path string, attribute *Attribute) (interface{}, error) {
+	values, found, err := e.collect(path)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, newUnknownAttributeError(attribute)
+	}
+	switch function {
+	case AggregateCount:
+		return float64(len(values)), nil
+	case AggregateDistinct:
+		distinct := make(map[string]bool, len(values))
+		for _, value := range values {
+			distinct[newSortKey(value, true).String()] = true
+		}
+		return float64(len(distinct)), nil
+	}
+
+	keys := make([]sortKey, len(values))
+	for i, value := range values {
+		keys[i] = newSortKey(value, true)
+		switch {
+		case function == AggregateSum || function == AggregateAvg:
+			if keys[i].rank != rankNumeric {
+				return nil, newIncomparableError(attribute, "numeric", fmt.Sprintf("%T", value))
+			}
+		case keys[i].rank != rankNumeric && keys[i].rank != rankTime:
+			return nil, newIncomparableError(attribute, "numeric or time", fmt.Sprintf("%T", value))
+		}
+		if i > 0 && keys[i].rank != keys[0].rank {
+			return nil, newIncomparableError(attribute, "numeric or time", "mixed values")
+		}
+	}
+	switch function {
+	case AggregateSum, AggregateAvg:
+		if function == AggregateSum && allIntegers(keys) {
+			var sum int64
+			for _, key := range keys {
+				sum += key.integer
+			}
+			return sum, nil
+		}
+		var sum float64
+		for _, key := range keys {
+			sum += key.number
+		}
+		if function == AggregateSum {
+			return sum, nil
+		}
+		if len(keys) == 0 {
+			return nil, nil
+		}
+		return sum / float64(len(keys)), nil
+	default:
+		if len(keys) == 0 {
+			return nil, nil
+		}
+		result := keys[0]
+		for _, key := range keys[1:] {
+			order := compareSortKeys(key, result, false)
+			if function == AggregateMin && order < 0 || function == AggregateMax && order > 0 {
+				result = key
+			}
+		}
+		if result.rank == rankTime {
+			return result.time, nil
+		}
+		return result.number, nil
+	}
+}
+
+func allIntegers(keys []sortKey) bool {
+	for _, key := range keys {
+		if !key.isInteger {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *evaluation) collect(path string) (values []interface{}, found bool, err error) {
+	resolve := e.resolver.Resolve
+	if resolver, ok := e.resolver.(aggregateResolver); ok {
+		resolve = resolver.resolveAggregate
+	}
+	if value, ok := resolve(path); ok {
+		value, err = e.provide(path, value)
+		return collectValues(reflect.ValueOf(value), "", nil), true, err
+	}
+	for i := len(path) - 1; i > 0; i-- {
+		if path[i] != '.' {
+			continue
+		}
+		value, ok := resolve(path[:i])
+		if !ok {
+			continue
+		}
+		value, err = e.provide(path[:i], value)
+		if err != nil {
+			return nil, true, err
+		}
+		rValue := indirectValue(reflect.ValueOf(value))
+		if rValue.Kind() != reflect.Slice && rValue.Kind() != reflect.Array {
+			return nil, false, nil
+		}
+		return collectValues(rValue, path[i+1:], nil), true, nil
+	}
+	return nil, false, nil
+}
+
+func (p *structPlan) collectionType(path string) (reflect.Type, bool) {
+	if field, ok := p.fields[path]; ok {
+		return collectType(field.rType, "")
+	}
+	for i := len(path) - 1; i > 0; i-- {
+		if path[i] != '.' {
+			continue
+		}
+		if field, ok := p.fields[path[:i]]; ok {
+			return collectType(field.rType, path[i+1:])
+		}
+	}
+	return nil, false
+}
+
+func collectType(rType reflect.Type, path string) (reflect.Type, bool) {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	if rType.Kind() == reflect.Interface || rType.Kind() == reflect.Func {
+		return nil, true
+	}
+	isCollection := (rType.Kind() == reflect.Slice || rType.Kind() == reflect.Array) && rType.Elem().Kind() != reflect.Uint8
+	if path == "" {
+		if isCollection {
+			return collectType(rType.Elem(), "")
+		}
+		return rType, true
+	}
+	segment, rest := path, ""
+	if index := strings.IndexByte(path, '.'); index >= 0 {
+		segment, rest = path[:index], path[index+1:]
+	}
+	if isCollection {
+		if _, err := strconv.Atoi(segment); err != nil {
+			return collectType(rType.Elem(), path)
+		}
+	}
+	switch rType.Kind() {
+	case reflect.Map:
+		if rType.Key().Kind() != reflect.String {
+			return nil, false
+		}
+		return collectType(rType.Elem(), rest)
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || rType.Kind() == reflect.Array && index >= rType.Len() {
+			return nil, false
+		}
+		return collectType(rType.Elem(), rest)
+	case reflect.Struct:
+		field, ok := getStructPlan(rType).fields[segment]
+		if !ok {
+			return nil, false
+		}
+		return collectType(field.rType, rest)
+	default:
+		return nil, false
+	}
+}
+
+func (p *structPlan) checkAggregate(function, path string, attribute *Attribute) error {
+	rType, ok := p.collectionType(path)
+	if !ok {
+		return &UnknownAttributeError{Attribute: attribute.Name}
+	}
+	if attribute.Operator == OperatorIsMissing || attribute.Operator == OperatorIsNotMissing {
+		return nil
+	}
+	if function == AggregateMin || function == AggregateMax {
+		if rType == timeType {
+			if _, err := parseTimeValue(attribute.Value); err != nil {
+				return newParseError(attribute, "time", err)
+			}
+			return nil
+		}
+		if rType == nil || rType.Kind() == reflect.String {
+			if _, err := parseTimeValue(attribute.Value); err == nil {
+				return nil
+			}
+		}
+	}
+	if _, err := strconv.ParseFloat(attribute.Value, 64); err != nil {
+		return newParseError(attribute, "number", err)
+	}
+	return nil
+}
+
+func collectValues(value reflect.Value, path string, values []interface{}) []interface{} {
+	value = indirectValue(value)
+	if !value.IsValid() {
+		return values
+	}
+	isCollection := (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && value.Type().Elem().Kind() != reflect.Uint8
+	if path == "" {
+		if !isCollection {
+			if value.CanInterface() {
+				values = append(values, value.Interface())
+			}
+			return values
+		}
+		for i := 0; i < value.Len(); i++ {
+			values = collectValues(value.Index(i), "", values)
+		}
+		return values
+	}
+	segment, rest := path, ""
+	if index := strings.IndexByte(path, '.'); index >= 0 {
+		segment, rest = path[:index], path[index+1:]
+	}
+	if isCollection {
+		if _, err := strconv.Atoi(segment); err != nil {
+			for i := 0; i < value.Len(); i++ {
+				values = collectValues(value.Index(i), path, values)
+			}
+			return values
+		}
+	}
+	if next, ok := lookupSegment(value, segment); ok {
+		values = collectValues(next, rest, values)
+	}
+	return values
+}
+
+func isRelativeTime(value string) bool {
+	return len(value) >= 3 && strings.EqualFold(value[:3], "now")
+}
+
+func parseTimeValue(value string) (time.Time, error) {
+	relative, ok := strings.CutPrefix(strings.ToLower(value), "now")
+	if !ok {
+		return time.Parse(DateTimeFormat, value)
+	}
+	now := time.Now()
+	if relative == "" {
+		return now, nil
+	}
+	sign := relative[0]
+	if len(relative) < 3 || sign != '-' && sign != '+' {
+		return time.Time{}, fmt.Errorf("invalid relative time %q", value)
+	}
+	amount, unit := relative[1:len(relative)-1], relative[len(relative)-1]
+	count, err := strconv.Atoi(amount)
+	if err != nil || count < 0 {
+		return time.Time{}, fmt.Errorf("invalid relative time %q", value)
+	}
+	var duration time.Duration
+	switch unit {
+	case 's':
+		duration = time.Second
+	case 'm':
+		duration = time.Minute
+	case 'h':
+		duration = time.Hour
+	case 'd':
+		duration = 24 * time.Hour
+	case 'w':
+		duration = 7 * 24 * time.Hour
+	default:
+		return time.Time{}, fmt.Errorf("invalid relative time unit %q", unit)
+	}
+	if sign == '-' {
+		count = -count
+	}
+	return now.Add(time.Duration(count) * duration), nil
+}
+
+func (k sortKey) String() string {
+	switch k.rank {
+	case rankNumeric:
+		return "n" + strconv.FormatFloat(k.number, 'g', -1, 64)
+	case rankTime:
+		return "t" + strconv.FormatInt(k.time.UnixNano(), 10)
+	case rankBool:
+		return "b" + strconv.FormatInt(k.integer, 10)
+	case rankText:
+		return "s" + k.text
+	default:
+		return ""
+	}
+}
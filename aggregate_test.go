@@ -0,0 +1,307 @@
+package astvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGenerateCondition_Aggregate(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []Attribute
+	}{
+		{
+			name:  "Normal case - aggregate attribute",
+			query: `sum(items.price) > 100000`,
+			want:  []Attribute{{Name: "sum(items.price)", Operator: ">", Value: "100000"}},
+		},
+		{
+			name:  "Normal case - aggregates inside groups",
+			query: `(avg( scores )>=80 || COUNT(items)=0) && max(logins.at) > now - 7d`,
+			want: []Attribute{
+				{Name: "avg(scores)", Operator: ">=", Value: "80"},
+				{Name: "COUNT(items)", Operator: "=", Value: "0"},
+				{Name: "max(logins.at)", Operator: ">", Value: "now-7d"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := GenerateCondition(tt.query)
+			if err != nil {
+				t.Fatalf("GenerateCondition() error = %v", err)
+			}
+			var got []Attribute
+			var walk func(c *Condition)
+			walk = func(c *Condition) {
+				if c.Attribute != nil {
+					got = append(got, *c.Attribute)
+				}
+				for _, sub := range c.Conditions {
+					walk(sub)
+				}
+			}
+			walk(&condition)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateCondition() attributes = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCondition_ValidateAggregate(t *testing.T) {
+	type Item struct {
+		Price    float64 `json:"price"`
+		Category string  `json:"category"`
+	}
+	type Login struct {
+		At time.Time `json:"at"`
+	}
+	type Order struct {
+		Items  []Item   `json:"items"`
+		Scores []int    `json:"scores"`
+		Logins []*Login `json:"logins"`
+		Tags   []string `json:"tags"`
+	}
+	recent := time.Now().Add(-48 * time.Hour)
+	order := Order{
+		Items: []Item{
+			{Price: 60000, Category: "book"},
+			{Price: 45000, Category: "toy"},
+			{Price: 5000, Category: "book"},
+		},
+		Scores: []int{70, 90, 85},
+		Logins: []*Login{{At: recent.Add(-30 * 24 * time.Hour)}, nil, {At: recent}},
+		Tags:   []string{"a", "b"},
+	}
+	document := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"price": "60000", "category": "book"},
+			map[string]interface{}{"price": 45000, "category": "toy"},
+		},
+		"logins": []interface{}{
+			map[string]interface{}{"at": recent.Format(DateTimeFormat)},
+		},
+	}
+	tests := []struct {
+		name         string
+		query        string
+		data         interface{}
+		opts         []Option
+		want         bool
+		wantErrIs    error
+		wantMismatch bool
+	}{
+		{
+			name:  "Normal case - sum over struct slice",
+			query: `sum(items.price) > 100000`,
+			data:  order,
+			want:  true,
+		},
+		{
+			name:  "Normal case - avg over scalar slice",
+			query: `avg(scores) >= 80 && avg(scores) < 82`,
+			data:  &order,
+			want:  true,
+		},
+		{
+			name:  "Normal case - max time with relative value skips nil elements",
+			query: `max(logins.at) > now - 7d && min(logins.at) < now-7d`,
+			data:  order,
+			want:  true,
+		},
+		{
+			name:  "Normal case - count and distinct",
+			query: `count(items) = 3 && distinct(items.category) >= 2 && distinct(items.category) < 3`,
+			data:  order,
+			want:  true,
+		},
+		{
+			name:  "Normal case - map document with numeric strings and time strings",
+			query: `sum(items.price) = 105000 && max(logins.at) > now-1w`,
+			data:  document,
+			want:  true,
+		},
+		{
+			name:  "Normal case - integer sum keeps int64 precision",
+			query: `sum(counters) = 9007199254740994 && sum(counters) > 9007199254740993`,
+			data:  map[string]interface{}{"counters": []interface{}{int64(9007199254740993), 1}},
+			want:  true,
+		},
+		{
+			name:  "Normal case - empty collection count and sum are zero",
+			query: `count(items) = 0 && sum(items.price) = 0`,
+			data:  Order{},
+			want:  true,
+		},
+		{
+			name:  "Normal case - empty collection avg is unknown",
+			query: `avg(scores) > 0 || avg(scores) <= 0`,
+			data:  Order{},
+			want:  false,
+		},
+		{
+			name:  "Normal case - empty collection max is missing",
+			query: `max(logins.at) is missing`,
+			data:  Order{},
+			want:  true,
+		},
+		{
+			name:      "Negative case - empty collection avg with unknown as error",
+			query:     `avg(scores) > 0`,
+			data:      Order{},
+			opts:      []Option{WithUnknownAs(UnknownAsError)},
+			wantErrIs: ErrUnknownResult,
+		},
+		{
+			name:  "Negative case - sum over text is false in lenient mode",
+			query: `sum(tags) > 0`,
+			data:  order,
+			want:  false,
+		},
+		{
+			name:         "Negative case - sum over text in strict mode",
+			query:        `sum(tags) > 0`,
+			data:         order,
+			opts:         []Option{WithMode(ModeStrict)},
+			wantMismatch: true,
+		},
+		{
+			name:      "Negative case - unknown collection in strict mode",
+			query:     `sum(lines.price) > 0`,
+			data:      document,
+			opts:      []Option{WithMode(ModeStrict)},
+			wantErrIs: ErrUnknownAttribute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := GenerateCondition(tt.query)
+			if err != nil {
+				t.Fatalf("GenerateCondition() error = %v", err)
+			}
+			validators := map[string]func() (bool, error){
+				"Validate": func() (bool, error) {
+					return condition.Validate(tt.data, tt.opts...)
+				},
+				"ValidateWithStats": func() (bool, error) {
+					isValid, _, err := condition.ValidateWithStats(tt.data, tt.opts...)
+					return isValid, err
+				},
+			}
+			for name, validate := range validators {
+				got, err := validate()
+				if tt.wantMismatch {
+					var mismatch *TypeMismatchError
+					if !errors.As(err, &mismatch) {
+						t.Errorf("%s() error = %v, want *TypeMismatchError", name, err)
+					}
+					continue
+				}
+				if tt.wantErrIs != nil {
+					if !errors.Is(err, tt.wantErrIs) {
+						t.Errorf("%s() error = %v, want %v", name, err, tt.wantErrIs)
+					}
+					continue
+				}
+				if err != nil {
+					t.Errorf("%s() error = %v", name, err)
+					continue
+				}
+				if got != tt.want {
+					t.Errorf("%s() = %v, want %v", name, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCondition_ValidateJSONAggregate(t *testing.T) {
+	condition, err := GenerateCondition(`sum(order.items.price) >= 150 && distinct(order.items.sku) = 2 && count(tags) = 0`)
+	if err != nil {
+		t.Fatalf("GenerateCondition() error = %v", err)
+	}
+	data := []byte(`{"order":{"items":[{"sku":"a","price":100},{"sku":"b","price":50},{"sku":"a","price":1}]},"tags":[],"skip":{"large":[1,2,3]}}`)
+	got, err := condition.ValidateJSON(data)
+	if err != nil {
+		t.Fatalf("ValidateJSON() error = %v", err)
+	}
+	if !got {
+		t.Errorf("ValidateJSON() = %v, want true", got)
+	}
+}
+
+func TestCompileFor_Aggregate(t *testing.T) {
+	type Item struct {
+		Price float64   `json:"price"`
+		Code  string    `json:"code"`
+		At    time.Time `json:"at"`
+	}
+	type Order struct {
+		Items []Item `json:"items"`
+		Total int    `json:"total"`
+	}
+	tests := []struct {
+		name      string
+		query     string
+		wantErrIs error
+		wantErrAs interface{}
+	}{
+		{
+			name:  "Normal case",
+			query: `sum(items.price) > 10 && count(items) >= 1`,
+		},
+		{
+			name:      "Negative case - unknown collection",
+			query:     `sum(lines.price) > 10`,
+			wantErrIs: ErrUnknownAttribute,
+		},
+		{
+			name:  "Normal case - time and string extremes",
+			query: `max(items.at) > now-7d && min(items.code) > 10 && max(items.code) < "2020-01-01 00:00:00"`,
+		},
+		{
+			name:      "Negative case - path through a scalar",
+			query:     `sum(total.price) > 10`,
+			wantErrIs: ErrUnknownAttribute,
+		},
+		{
+			name:      "Negative case - unknown element field",
+			query:     `max(items.nope) > 1`,
+			wantErrIs: ErrUnknownAttribute,
+		},
+		{
+			name:      "Error case - invalid numeric literal",
+			query:     `sum(items.price) > abc`,
+			wantErrAs: new(*ParseError),
+		},
+		{
+			name:      "Error case - numeric literal for a time extreme",
+			query:     `max(items.at) > 10`,
+			wantErrAs: new(*ParseError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CompileFor(tt.query, reflect.TypeOf(Order{}))
+			if tt.wantErrAs != nil {
+				if !errors.As(err, tt.wantErrAs) {
+					t.Errorf("CompileFor() error = %v, want %T", err, tt.wantErrAs)
+				}
+				return
+			}
+			if tt.wantErrIs == nil {
+				if err != nil {
+					t.Errorf("CompileFor() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("CompileFor() error = %v, want %v", err, tt.wantErrIs)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package astvalidator
+
+import (
+	"context"
+	"reflect"
+)
+
+type Result struct {
+	Index int    `json:"index"`
+	Match bool   `json:"match"`
+	Err   error  `json:"-"`
+	Error string `json:"error,omitempty"`
+	Trace *Trace `json:"trace,omitempty"`
+}
+
+type Partition[T any] struct {
+	Matched   []T
+	Unmatched []T
+	Errored   []T
+	Errors    []*ElementError
+}
+
+func WithExplain() Option {
+	return func(e *evaluation) {
+		e.explain = true
+	}
+}
+
+func (c *Condition) ValidateBatch(data interface{}, opts ...Option) (results []Result, err error) {
+	if data == nil {
+		return nil, ErrNilData
+	}
+	data, err = indirect(data)
+	if err != nil {
+		return nil, err
+	}
+	rType := reflect.TypeOf(data)
+	if rType.Kind() != reflect.Slice {
+		return nil, newUnsupportedTypeError("slice", rType)
+	}
+	rValue := reflect.ValueOf(data)
+	results = make([]Result, rValue.Len())
+	if c.resolveOptions(opts).explain {
+		for i := range results {
+			results[i] = c.explainElement(i, rValue.Index(i).Interface(), opts)
+		}
+		return results, nil
+	}
+	match, release := c.elementMatcher(context.Background(), rValue, opts)
+	defer release()
+	for i := range results {
+		isValid, err := match(i)
+		results[i] = newResult(i, isValid, err, nil)
+	}
+	return results, nil
+}
+
+func PartitionSlice[T any](c *Condition, values []T, opts ...Option) Partition[T] {
+	results, err := c.ValidateBatch(values, opts...)
+	if err != nil {
+		errored := make([]*ElementError, len(values))
+		for i := range errored {
+			errored[i] = &ElementError{Index: i, Err: err}
+		}
+		return Partition[T]{Errored: values, Errors: errored}
+	}
+	return partition(values, results)
+}
+
+func (p Predicate[T]) Batch(values []T, opts ...Option) []Result {
+	results := make([]Result, len(values))
+	if p.condition.resolveOptions(opts).explain {
+		for i, value := range values {
+			results[i] = p.condition.explainElement(i, value, opts)
+		}
+		return results
+	}
+	match := p.matcher(values, opts)
+	for i := range results {
+		isValid, err := match(i)
+		results[i] = newResult(i, isValid, err, nil)
+	}
+	return results
+}
+
+func (p Predicate[T]) Partition(values []T, opts ...Option) Partition[T] {
+	return partition(values, p.Batch(values, opts...))
+}
+
+func (c *Condition) explainElement(index int, value interface{}, opts []Option) Result {
+	isValid, trace, err := c.ValidateWithTrace(value, opts...)
+	return newResult(index, isValid, err, trace)
+}
+
+func newResult(index int, isValid bool, err error, trace *Trace) Result {
+	result := Result{Index: index, Match: isValid, Err: err, Trace: trace}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func partition[T any](values []T, results []Result) Partition[T] {
+	var p Partition[T]
+	for i, result := range results {
+		switch {
+		case result.Err != nil:
+			p.Errored = append(p.Errored, values[i])
+			p.Errors = append(p.Errors, &ElementError{Index: i, Err: result.Err})
+		case result.Match:
+			p.Matched = append(p.Matched, values[i])
+		default:
+			p.Unmatched = append(p.Unmatched, values[i])
+		}
+	}
+	return p
+}
@@ -0,0 +1,218 @@
+package astvalidator
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestCondition_ValidateBatch(t *testing.T) {
+	type Row struct {
+		ID     int    `json:"id"`
+		Status string `json:"status"`
+		Amount string `json:"amount"`
+	}
+	rows := []Row{
+		{ID: 1, Status: "active", Amount: "150"},
+		{ID: 2, Status: "active", Amount: "20"},
+		{ID: 3, Status: "active", Amount: "a lot"},
+		{ID: 4, Status: "closed", Amount: "500"},
+	}
+	documents := []map[string]interface{}{
+		{"status": "active", "amount": 150},
+		{"status": "active", "amount": "a lot"},
+	}
+	tests := []struct {
+		name        string
+		query       string
+		data        interface{}
+		opts        []Option
+		wantMatch   []bool
+		wantErrAt   []int
+		wantTrace   bool
+		wantErrIs   error
+		wantErrType error
+	}{
+		{
+			name:      "Normal case - struct slice",
+			query:     `status=active && amount>100`,
+			data:      rows,
+			wantMatch: []bool{true, false, false, false},
+		},
+		{
+			name:      "Normal case - element errors do not stop the batch",
+			query:     `status=active && amount>100`,
+			data:      &rows,
+			opts:      []Option{WithMode(ModeStrict)},
+			wantMatch: []bool{true, false, false, false},
+			wantErrAt: []int{2},
+		},
+		{
+			name:      "Normal case - map slice with explanation",
+			query:     `status=active && amount>100`,
+			data:      documents,
+			opts:      []Option{WithMode(ModeStrict), WithExplain()},
+			wantMatch: []bool{true, false},
+			wantErrAt: []int{1},
+			wantTrace: true,
+		},
+		{
+			name:      "Negative case - nil data",
+			query:     `status=active`,
+			wantErrIs: ErrNilData,
+		},
+		{
+			name:        "Negative case - not a slice",
+			query:       `status=active`,
+			data:        rows[0],
+			wantErrType: &UnsupportedTypeError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := GenerateCondition(tt.query)
+			if err != nil {
+				t.Fatalf("GenerateCondition() error = %v", err)
+			}
+			results, err := condition.ValidateBatch(tt.data, tt.opts...)
+			if tt.wantErrIs != nil || tt.wantErrType != nil {
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("ValidateBatch() error = %v, want %v", err, tt.wantErrIs)
+				}
+				if tt.wantErrType != nil && reflect.TypeOf(err) != reflect.TypeOf(tt.wantErrType) {
+					t.Errorf("ValidateBatch() error = %T, want %T", err, tt.wantErrType)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateBatch() error = %v", err)
+			}
+			var gotMatch []bool
+			var gotErrAt []int
+			for i, result := range results {
+				if result.Index != i {
+					t.Errorf("ValidateBatch() result %d index = %d", i, result.Index)
+				}
+				if (result.Trace != nil) != tt.wantTrace {
+					t.Errorf("ValidateBatch() result %d trace = %v, want trace %v", i, result.Trace, tt.wantTrace)
+				}
+				gotMatch = append(gotMatch, result.Match)
+				if result.Err != nil {
+					gotErrAt = append(gotErrAt, i)
+				}
+				if result.Err != nil && result.Error != result.Err.Error() || result.Err == nil && result.Error != "" {
+					t.Errorf("ValidateBatch() result %d error = %q, want %v", i, result.Error, result.Err)
+				}
+			}
+			if !reflect.DeepEqual(gotMatch, tt.wantMatch) {
+				t.Errorf("ValidateBatch() matches = %v, want %v", gotMatch, tt.wantMatch)
+			}
+			if !reflect.DeepEqual(gotErrAt, tt.wantErrAt) {
+				t.Errorf("ValidateBatch() error indexes = %v, want %v", gotErrAt, tt.wantErrAt)
+			}
+		})
+	}
+}
+
+func TestResult_MarshalJSON(t *testing.T) {
+	type Row struct {
+		Amount string `json:"amount"`
+	}
+	predicate, err := Compile[Row](`amount>100`)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	results := predicate.Batch([]Row{{Amount: "150"}, {Amount: "a lot"}}, WithMode(ModeStrict))
+	got, err := json.Marshal(results)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `[{"index":0,"match":true},{"index":1,"match":false,"error":` + strconv.Quote(results[1].Err.Error()) + `}]`
+	if string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	type Row struct {
+		ID     int    `json:"id"`
+		Amount string `json:"amount"`
+	}
+	rows := []Row{
+		{ID: 1, Amount: "150"},
+		{ID: 2, Amount: "n/a"},
+		{ID: 3, Amount: "20"},
+		{ID: 4, Amount: "300"},
+	}
+	ids := func(rows []Row) []int {
+		var result []int
+		for _, row := range rows {
+			result = append(result, row.ID)
+		}
+		return result
+	}
+	tests := []struct {
+		name          string
+		query         string
+		opts          []Option
+		wantMatched   []int
+		wantUnmatched []int
+		wantErrored   []int
+	}{
+		{
+			name:          "Normal case - lenient",
+			query:         `amount>100`,
+			wantMatched:   []int{1, 4},
+			wantUnmatched: []int{2, 3},
+		},
+		{
+			name:          "Normal case - strict",
+			query:         `amount>100`,
+			opts:          []Option{WithMode(ModeStrict)},
+			wantMatched:   []int{1, 4},
+			wantUnmatched: []int{3},
+			wantErrored:   []int{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := GenerateCondition(tt.query)
+			if err != nil {
+				t.Fatalf("GenerateCondition() error = %v", err)
+			}
+			predicate, err := Compile[Row](tt.query, tt.opts...)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			plain, err := Compile[Row](tt.query)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			for name, got := range map[string]Partition[Row]{
+				"PartitionSlice":              PartitionSlice(&condition, rows, tt.opts...),
+				"Predicate.Partition":         predicate.Partition(rows),
+				"Predicate.Partition options": plain.Partition(rows, tt.opts...),
+			} {
+				if !reflect.DeepEqual(ids(got.Matched), tt.wantMatched) {
+					t.Errorf("%s() matched = %v, want %v", name, ids(got.Matched), tt.wantMatched)
+				}
+				if !reflect.DeepEqual(ids(got.Unmatched), tt.wantUnmatched) {
+					t.Errorf("%s() unmatched = %v, want %v", name, ids(got.Unmatched), tt.wantUnmatched)
+				}
+				if !reflect.DeepEqual(ids(got.Errored), tt.wantErrored) {
+					t.Errorf("%s() errored = %v, want %v", name, ids(got.Errored), tt.wantErrored)
+				}
+				if len(got.Errors) != len(got.Errored) {
+					t.Fatalf("%s() errors = %d, want %d", name, len(got.Errors), len(got.Errored))
+				}
+				for i, err := range got.Errors {
+					if rows[err.Index].ID != got.Errored[i].ID {
+						t.Errorf("%s() error index = %d, want row %d", name, err.Index, got.Errored[i].ID)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package astvalidator
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+type Catalog struct {
+	mutex    sync.RWMutex
+	messages map[string]map[string]string
+}
+
+var defaultCatalog = &Catalog{
+	messages: map[string]map[string]string{
+		LocaleEnglish: {
+			ErrorCodeEqual:            "{field} must be equal to {limit}",
+			ErrorCodeLessThan:         "{field} must be less than {limit}",
+			ErrorCodeLessThanEqual:    "{field} must be less than or equal to {limit}",
+			ErrorCodeGreaterThan:      "{field} must be greater than {limit}",
+			ErrorCodeGreaterThanEqual: "{field} must be greater than or equal to {limit}",
+			ErrorCodeMissing:          "{field} is required",
+		},
+		LocaleIndonesian: {
+			ErrorCodeEqual:            "{field} harus sama dengan {limit}",
+			ErrorCodeLessThan:         "{field} harus kurang dari {limit}",
+			ErrorCodeLessThanEqual:    "{field} harus kurang dari atau sama dengan {limit}",
+			ErrorCodeGreaterThan:      "{field} harus lebih dari {limit}",
+			ErrorCodeGreaterThanEqual: "{field} harus lebih dari atau sama dengan {limit}",
+			ErrorCodeMissing:          "{field} wajib diisi",
+		},
+	},
+}
+
+func NewCatalog() *Catalog {
+	return &Catalog{
+		messages: make(map[string]map[string]string),
+	}
+}
+
+func LoadCatalog(reader io.Reader) (*Catalog, error) {
+	catalog := NewCatalog()
+	if err := catalog.Load(reader); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+func LoadCatalogFile(path string) (*Catalog, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadCatalog(file)
+}
+
+func (c *Catalog) Load(reader io.Reader) error {
+	messages := make(map[string]map[string]string)
+	if err := json.NewDecoder(reader).Decode(&messages); err != nil {
+		return err
+	}
+	for locale, codes := range messages {
+		for code, template := range codes {
+			c.Add(locale, code, template)
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) Add(locale, code, template string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	locale = strings.ToLower(locale)
+	if c.messages == nil {
+		c.messages = make(map[string]map[string]string)
+	}
+	if _, ok := c.messages[locale]; !ok {
+		c.messages[locale] = make(map[string]string)
+	}
+	c.messages[locale][code] = template
+}
+
+func (c *Catalog) Message(locale, code string) (string, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	locale = strings.ToLower(locale)
+	for {
+		if template, ok := c.messages[locale][code]; ok {
+			return template, true
+		}
+		index := strings.LastIndexAny(locale, "-_")
+		if index < 0 {
+			return "", false
+		}
+		locale = locale[:index]
+	}
+}
+
+func (e *evaluation) message(code, ruleCode, ruleMessage string) string {
+	if ruleMessage != "" && ruleCode == "" {
+		return ruleMessage
+	}
+	if e.catalog != nil {
+		if template, ok := e.catalog.Message(e.locale, code); ok {
+			return template
+		}
+	}
+	if ruleMessage != "" {
+		return ruleMessage
+	}
+	if template, ok := defaultCatalog.Message(e.locale, code); ok {
+		return template
+	}
+	template, _ := defaultCatalog.Message(DefaultLocale, code)
+	return template
+}
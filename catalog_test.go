@@ -0,0 +1,94 @@
+package astvalidator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadCatalogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.json")
+	content := `{
+		"en": {"adult_required": "{field} must be at least {limit}, got {value}", "greater_than_equal": "generic {field} >= {limit}"},
+		"id": {"adult_required": "{field} minimal {limit}, bukan {value}"}
+	}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	catalog, err := LoadCatalogFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCatalogFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("LoadCatalogFile() expected error for missing file")
+	}
+
+	type Request struct {
+		Age   int `json:"age"`
+		Limit int `json:"limit"`
+		Score int `json:"score"`
+	}
+	condition, _ := GenerateCondition(`age >= 18 : adult_required : "must be an adult" && limit < 10 && score >= 5 : "score is too low"`)
+	data := Request{Age: 16, Limit: 12, Score: 1}
+
+	tests := []struct {
+		name         string
+		opts         []Option
+		wantMessages []string
+	}{
+		{
+			name:         "Normal case - english catalog",
+			opts:         []Option{WithCatalog(catalog), WithLocale(LocaleEnglish)},
+			wantMessages: []string{"age must be at least 18, got 16", "limit must be less than 10", "score is too low"},
+		},
+		{
+			name:         "Normal case - indonesian catalog and built-in message",
+			opts:         []Option{WithCatalog(catalog), WithLocale(LocaleIndonesian)},
+			wantMessages: []string{"age minimal 18, bukan 16", "limit harus kurang dari 10", "score is too low"},
+		},
+		{
+			name:         "Normal case - regional locale falls back to language",
+			opts:         []Option{WithCatalog(catalog), WithLocale("id-ID")},
+			wantMessages: []string{"age minimal 18, bukan 16", "limit harus kurang dari 10", "score is too low"},
+		},
+		{
+			name:         "Normal case - rule message without catalog",
+			opts:         []Option{WithLocale(LocaleIndonesian)},
+			wantMessages: []string{"must be an adult", "limit harus kurang dari 10", "score is too low"},
+		},
+		{
+			name:         "Normal case - unknown locale uses default",
+			opts:         []Option{WithCatalog(catalog), WithLocale("fr")},
+			wantMessages: []string{"must be an adult", "limit must be less than 10", "score is too low"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErrors ValidationErrors
+			if err := condition.Check(data, tt.opts...); !errors.As(err, &validationErrors) {
+				t.Errorf("Condition.Check() error = %v, want ValidationErrors", err)
+				return
+			}
+			gotMessages := make([]string, len(validationErrors))
+			for i, fieldError := range validationErrors {
+				gotMessages[i] = fieldError.Message
+			}
+			if !reflect.DeepEqual(gotMessages, tt.wantMessages) {
+				t.Errorf("Condition.Check() messages = %v, want %v", gotMessages, tt.wantMessages)
+			}
+		})
+	}
+}
+
+func TestCatalog_Add(t *testing.T) {
+	var catalog Catalog
+	catalog.Add("EN", "adult_required", "{field} must be an adult")
+	if got, ok := catalog.Message("en-US", "adult_required"); !ok || got != "{field} must be an adult" {
+		t.Errorf("Catalog.Message() = %q, %v, want the added template", got, ok)
+	}
+	if _, ok := catalog.Message("en", "missing"); ok {
+		t.Errorf("Catalog.Message() found a code that was not added")
+	}
+}
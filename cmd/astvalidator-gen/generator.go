@@ -0,0 +1,485 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahmadrezamusthafa/astvalidator"
+	"github.com/ahmadrezamusthafa/astvalidator/internal/valuetype"
+)
+
+const importPath = "github.com/ahmadrezamusthafa/astvalidator"
+
+var (
+	mapGoOperator = map[string]string{
+		astvalidator.OperatorEqual:            "==",
+		astvalidator.OperatorLessThan:         "<",
+		astvalidator.OperatorLessThanEqual:    "<=",
+		astvalidator.OperatorGreaterThan:      ">",
+		astvalidator.OperatorGreaterThanEqual: ">=",
+	}
+
+	mapKind = map[string]string{
+		"int": "int", "int8": "int", "int16": "int", "int32": "int", "int64": "int", "rune": "int",
+		"uint": "uint", "uint8": "uint", "uint16": "uint", "uint32": "uint", "uint64": "uint", "byte": "uint",
+		"float32": "float", "float64": "float",
+		"bool":   "bool",
+		"string": "string",
+	}
+
+	mapBitSize = map[string]int{
+		"int8": 8, "int16": 16, "int32": 32, "rune": 32,
+		"uint8": 8, "uint16": 16, "uint32": 32, "byte": 8,
+	}
+)
+
+type goPackage struct {
+	name  string
+	types map[string]ast.Expr
+}
+
+type fieldPath struct {
+	path     string
+	value    string
+	goType   string
+	kind     string
+	pointers []pointerStep
+}
+
+type pointerStep struct {
+	expr     string
+	elemType string
+}
+
+type generator struct {
+	pkg       *goPackage
+	typeName  string
+	funcName  string
+	param     string
+	query     string
+	condition astvalidator.Condition
+	paths     map[string]*fieldPath
+	order     []string
+	literals  map[string][]string
+	imports   map[string]bool
+	statement *bytes.Buffer
+	leaves    int
+}
+
+func loadPackage(dir string) (*goPackage, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	pkg := &goPackage{types: map[string]ast.Expr{}}
+	fileSet := token.NewFileSet()
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".go") || strings.HasSuffix(file.Name(), "_test.go") {
+			continue
+		}
+		parsed, err := parser.ParseFile(fileSet, filepath.Join(dir, file.Name()), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		pkg.name = parsed.Name.Name
+		for _, decl := range parsed.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				pkg.types[typeSpec.Name.Name] = typeSpec.Type
+			}
+		}
+	}
+	if pkg.name == "" {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return pkg, nil
+}
+
+func newGenerator(pkg *goPackage, typeName, funcName, query string) (*generator, error) {
+	if _, ok := pkg.types[typeName].(*ast.StructType); !ok {
+		return nil, fmt.Errorf("struct type %s not found in package %s", typeName, pkg.name)
+	}
+	condition, err := astvalidator.GenerateCondition(query)
+	if err != nil {
+		return nil, err
+	}
+	return &generator{
+		pkg:       pkg,
+		typeName:  typeName,
+		funcName:  funcName,
+		param:     strings.ToLower(typeName[:1]),
+		query:     query,
+		condition: condition,
+		paths:     map[string]*fieldPath{},
+		literals:  map[string][]string{},
+		imports:   map[string]bool{},
+		statement: &bytes.Buffer{},
+	}, nil
+}
+
+func (g *generator) generate() ([]byte, error) {
+	expression, err := g.expression(&g.condition)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, "// Code generated by astvalidator-gen. DO NOT EDIT.\n\npackage %s\n\n", g.pkg.name)
+	g.writeImports(buffer, g.imports)
+	fmt.Fprintf(buffer, "// %s reports whether %s satisfies the condition:\n//\n//\t%s\n", g.funcName, g.param, g.query)
+	fmt.Fprintf(buffer, "func %s(%s *%s) bool {\n", g.funcName, g.param, g.typeName)
+	fmt.Fprintf(buffer, "if %s == nil {\nreturn false\n}\n", g.param)
+	buffer.WriteString("// unknown lies between false and true, so && is min and || is max\n")
+	buffer.WriteString("const (\nisFalse = iota\nisUnknown\nisTrue\n)\n")
+	buffer.Write(g.statement.Bytes())
+	fmt.Fprintf(buffer, "return %s == isTrue\n}\n", expression)
+	return format.Source(buffer.Bytes())
+}
+
+func (g *generator) expression(c *astvalidator.Condition) (string, error) {
+	if len(c.Conditions) == 0 {
+		return g.leaf(c.Attribute)
+	}
+	var (
+		terms    []string
+		function string
+	)
+	for i, subCondition := range c.Conditions {
+		subExpression, err := g.expression(subCondition)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			next := "min"
+			if subCondition.Operator == astvalidator.LogicalOperatorOr {
+				next = "max"
+			}
+			if len(terms) > 1 && next != function {
+				terms = []string{combine(function, terms)}
+			}
+			function = next
+		}
+		terms = append(terms, subExpression)
+	}
+	return combine(function, terms), nil
+}
+
+func (g *generator) leaf(attribute *astvalidator.Attribute) (string, error) {
+	if attribute == nil || attribute.Name == "" {
+		return "", fmt.Errorf("empty condition")
+	}
+	if strings.HasSuffix(attribute.Name, ")") && strings.Contains(attribute.Name, "(") {
+		return "", fmt.Errorf("attribute %s: aggregates are not supported", attribute.Name)
+	}
+	field, err := g.resolve(attribute.Name)
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("t%d", g.leaves)
+	g.leaves++
+
+	isMissing := attribute.Operator == astvalidator.OperatorIsMissing
+	switch {
+	case isMissing || attribute.Operator == astvalidator.OperatorIsNotMissing:
+		if len(field.pointers) == 0 {
+			g.declare(name, nil, "", strconv.FormatBool(!isMissing))
+			return name, nil
+		}
+		guards := make([]string, len(field.pointers))
+		for i, step := range field.pointers {
+			if isMissing {
+				guards[i] = step.expr + " == nil"
+			} else {
+				guards[i] = step.expr + " != nil"
+			}
+		}
+		separator := " && "
+		if isMissing {
+			separator = " || "
+		}
+		g.declare(name, nil, "", strings.Join(guards, separator))
+		return name, nil
+	}
+
+	if field.kind == "" {
+		return "", fmt.Errorf("attribute %s: unsupported type %s", attribute.Name, field.goType)
+	}
+	condition, init, err := g.compare(field, attribute)
+	if err != nil {
+		return "", err
+	}
+	g.literals[field.path] = append(g.literals[field.path], attribute.Value)
+	guards := make([]string, len(field.pointers))
+	for i, step := range field.pointers {
+		guards[i] = step.expr + " != nil"
+	}
+	g.declare(name, guards, init, condition)
+	return name, nil
+}
+
+func (g *generator) declare(name string, guards []string, init, condition string) {
+	set := ""
+	switch {
+	case condition == "true":
+		set = name + " = isTrue\n"
+	case condition == "false":
+	case init != "":
+		set = fmt.Sprintf("if %s; %s {\n%s = isTrue\n}\n", init, condition, name)
+	default:
+		set = fmt.Sprintf("if %s {\n%s = isTrue\n}\n", condition, name)
+	}
+	switch {
+	case len(guards) > 0:
+		fmt.Fprintf(g.statement, "%s := isUnknown\nif %s {\n%s = isFalse\n%s}\n", name, strings.Join(guards, " && "), name, set)
+	case condition == "true":
+		fmt.Fprintf(g.statement, "%s := isTrue\n", name)
+	default:
+		fmt.Fprintf(g.statement, "%s := isFalse\n%s", name, set)
+	}
+}
+
+func (g *generator) compare(field *fieldPath, attribute *astvalidator.Attribute) (condition, init string, err error) {
+	operator, ok := mapGoOperator[attribute.Operator]
+	if !ok {
+		return "", "", fmt.Errorf("attribute %s: unsupported operator %q", attribute.Name, attribute.Operator)
+	}
+	switch field.kind {
+	case "int":
+		value, err := strconv.ParseInt(attribute.Value, 10, 64)
+		if err != nil {
+			return "", "", fmt.Errorf("attribute %s: unable to parse %q as integer", attribute.Name, attribute.Value)
+		}
+		return fmt.Sprintf("int64(%s) %s %d", field.value, operator, value), "", nil
+	case "uint":
+		value, err := strconv.ParseInt(attribute.Value, 10, 64)
+		if err != nil {
+			return "", "", fmt.Errorf("attribute %s: unable to parse %q as integer", attribute.Name, attribute.Value)
+		}
+		switch {
+		case attribute.Operator != astvalidator.OperatorEqual:
+			return fmt.Sprintf("float64(%s) %s %d", field.value, operator, value), "", nil
+		case value < 0:
+			return "false", "", nil
+		default:
+			return fmt.Sprintf("uint64(%s) == %d", field.value, value), "", nil
+		}
+	case "float":
+		value, err := strconv.ParseFloat(attribute.Value, 64)
+		if err != nil {
+			return "", "", fmt.Errorf("attribute %s: unable to parse %q as float", attribute.Name, attribute.Value)
+		}
+		return fmt.Sprintf("float64(%s) %s %s", field.value, operator, formatFloat(value)), "", nil
+	case "bool":
+		switch {
+		case attribute.Operator != astvalidator.OperatorEqual:
+			return "false", "", nil
+		case attribute.Value == "t" || attribute.Value == "true":
+			return field.value, "", nil
+		default:
+			return "!" + field.value, "", nil
+		}
+	case "time":
+		value, err := time.Parse(astvalidator.DateTimeFormat, attribute.Value)
+		if err != nil {
+			return "", "", fmt.Errorf("attribute %s: unable to parse %q as time", attribute.Name, attribute.Value)
+		}
+		g.imports["time"] = true
+		return timeExpression(field.value, attribute.Operator, value), "", nil
+	}
+
+	if attribute.Operator == astvalidator.OperatorEqual {
+		return fmt.Sprintf("%s == %s", field.value, strconv.Quote(attribute.Value)), "", nil
+	}
+	switch valuetype.Of(attribute.Value) {
+	case valuetype.Numeric:
+		value, _ := strconv.ParseFloat(attribute.Value, 64)
+		g.imports["strconv"] = true
+		return fmt.Sprintf("err == nil && value %s %s", operator, formatFloat(value)),
+			fmt.Sprintf("value, err := strconv.ParseFloat(string(%s), 64)", field.value), nil
+	case valuetype.Time:
+		value, _ := time.Parse(valuetype.DateTimeFormat, attribute.Value)
+		g.imports["time"] = true
+		return "err == nil && " + timeExpression("value", attribute.Operator, value),
+			fmt.Sprintf("value, err := time.Parse(%q, string(%s))", valuetype.DateTimeFormat, field.value), nil
+	default:
+		return "false", "", nil
+	}
+}
+
+func (g *generator) resolve(path string) (*fieldPath, error) {
+	if field, ok := g.paths[path]; ok {
+		return field, nil
+	}
+	field := &fieldPath{path: path}
+
+	expression := g.param
+	structType := g.pkg.types[g.typeName].(*ast.StructType)
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		goName, typeExpr, ok := g.findField(structType, segment)
+		if !ok {
+			return nil, fmt.Errorf("attribute %s: %s is not an exported field", path, segment)
+		}
+		expression += "." + goName
+		if star, ok := typeExpr.(*ast.StarExpr); ok {
+			typeExpr = star.X
+			field.pointers = append(field.pointers, pointerStep{expr: expression, elemType: typeString(typeExpr)})
+		}
+		if i < len(segments)-1 {
+			ident, ok := typeExpr.(*ast.Ident)
+			if !ok {
+				return nil, fmt.Errorf("attribute %s: %s is not a struct of package %s", path, segment, g.pkg.name)
+			}
+			structType, ok = g.pkg.types[ident.Name].(*ast.StructType)
+			if !ok {
+				return nil, fmt.Errorf("attribute %s: %s is not a struct of package %s", path, segment, g.pkg.name)
+			}
+			continue
+		}
+		field.goType = typeString(typeExpr)
+		field.kind = g.kind(typeExpr)
+		field.value = expression
+		if len(field.pointers) > 0 && field.pointers[len(field.pointers)-1].expr == expression {
+			field.value = "*" + expression
+		}
+	}
+	g.paths[path] = field
+	g.order = append(g.order, path)
+	return field, nil
+}
+
+func (g *generator) findField(structType *ast.StructType, name string) (string, ast.Expr, bool) {
+	for _, astField := range structType.Fields.List {
+		tagName, hasTag := "", false
+		if astField.Tag != nil {
+			tag, _ := strconv.Unquote(astField.Tag.Value)
+			if jsonTag, ok := reflect.StructTag(tag).Lookup("json"); ok && jsonTag != "" {
+				hasTag = true
+				tagName = strings.Split(jsonTag, ",")[0]
+			}
+		}
+		if len(astField.Names) == 0 {
+			ident, ok := astField.Type.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if embedded, ok := g.pkg.types[ident.Name].(*ast.StructType); ok && !hasTag {
+				if goName, typeExpr, ok := g.findField(embedded, name); ok {
+					return goName, typeExpr, true
+				}
+				continue
+			}
+			if fieldName(ident.Name, tagName) == name && ast.IsExported(ident.Name) {
+				return ident.Name, astField.Type, true
+			}
+			continue
+		}
+		for _, ident := range astField.Names {
+			if ast.IsExported(ident.Name) && fieldName(ident.Name, tagName) == name {
+				return ident.Name, astField.Type, true
+			}
+		}
+	}
+	return "", nil, false
+}
+
+func (g *generator) kind(typeExpr ast.Expr) string {
+	switch typeExpr := typeExpr.(type) {
+	case *ast.SelectorExpr:
+		if typeString(typeExpr) == "time.Time" {
+			return "time"
+		}
+	case *ast.Ident:
+		if kind, ok := mapKind[typeExpr.Name]; ok {
+			return kind
+		}
+		if underlying, ok := g.pkg.types[typeExpr.Name]; ok {
+			if _, isStruct := underlying.(*ast.StructType); !isStruct {
+				return g.kind(underlying)
+			}
+		}
+	}
+	return ""
+}
+
+func (g *generator) writeImports(buffer *bytes.Buffer, imports map[string]bool) {
+	standard := []string{}
+	for _, path := range []string{"math/rand", "strconv", "testing", "time"} {
+		if imports[path] {
+			standard = append(standard, strconv.Quote(path))
+		}
+	}
+	if len(standard) == 0 && !imports[importPath] {
+		return
+	}
+	buffer.WriteString("import (\n" + strings.Join(standard, "\n") + "\n")
+	if imports[importPath] {
+		fmt.Fprintf(buffer, "\n%q\n", importPath)
+	}
+	buffer.WriteString(")\n\n")
+}
+
+func combine(function string, terms []string) string {
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return function + "(" + strings.Join(terms, ", ") + ")"
+}
+
+func fieldName(goName, tagName string) string {
+	if tagName != "" {
+		return tagName
+	}
+	return goName
+}
+
+func typeString(typeExpr ast.Expr) string {
+	switch typeExpr := typeExpr.(type) {
+	case *ast.Ident:
+		return typeExpr.Name
+	case *ast.SelectorExpr:
+		return typeString(typeExpr.X) + "." + typeExpr.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(typeExpr.X)
+	default:
+		return fmt.Sprintf("%T", typeExpr)
+	}
+}
+
+func formatFloat(value float64) string {
+	formatted := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(formatted, ".e") {
+		formatted += ".0"
+	}
+	return formatted
+}
+
+func timeExpression(value, operator string, limit time.Time) string {
+	literal := timeLiteral(limit)
+	switch operator {
+	case astvalidator.OperatorEqual:
+		return fmt.Sprintf("%s.Equal(%s)", value, literal)
+	case astvalidator.OperatorGreaterThan:
+		return fmt.Sprintf("%s.After(%s)", value, literal)
+	case astvalidator.OperatorLessThan:
+		return fmt.Sprintf("%s.Before(%s)", value, literal)
+	case astvalidator.OperatorGreaterThanEqual:
+		return fmt.Sprintf("!%s.Before(%s)", value, literal)
+	default:
+		return fmt.Sprintf("!%s.After(%s)", value, literal)
+	}
+}
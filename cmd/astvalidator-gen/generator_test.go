@@ -0,0 +1,148 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSource = `package sample
+
+import "time"
+
+type Level int
+
+type Profile struct {
+	City string ` + "`json:\"city\"`" + `
+}
+
+type Account struct {
+	ID       int               ` + "`json:\"id\"`" + `
+	Level    Level             ` + "`json:\"level\"`" + `
+	JoinDate time.Time         ` + "`json:\"join_date\"`" + `
+	Email    *string           ` + "`json:\"email\"`" + `
+	Manager  *Profile          ` + "`json:\"manager\"`" + `
+	Tags     map[string]string ` + "`json:\"tags\"`" + `
+	secret   string
+}
+`
+
+func TestGenerate(t *testing.T) {
+	dir, err := os.MkdirTemp("", "astvalidator-gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.WriteFile(filepath.Join(dir, "account.go"), []byte(testSource), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		typeName string
+		query    string
+		want     []string
+		wantErr  string
+	}{
+		{
+			name:     "Normal case - scalar, named and time fields",
+			typeName: "Account",
+			query:    `id>=1 && level=2 && join_date<"2016-01-01 00:00:00"`,
+			want: []string{
+				"func MatchAccount(a *Account) bool {",
+				"t0 := isFalse\n\tif int64(a.ID) >= 1 {\n\t\tt0 = isTrue",
+				"if int64(a.Level) == 2 {\n\t\tt1 = isTrue",
+				"a.JoinDate.Before(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC))",
+				"return min(t0, t1, t2) == isTrue",
+			},
+		},
+		{
+			name:     "Normal case - nullable fields",
+			typeName: "Account",
+			query:    `email=x || manager.city=jakarta || id is missing`,
+			want: []string{
+				"t0 := isUnknown\n\tif a.Email != nil {\n\t\tt0 = isFalse\n\t\tif *a.Email == \"x\" {",
+				"if a.Manager != nil {\n\t\tt1 = isFalse\n\t\tif a.Manager.City == \"jakarta\" {",
+				"t2 := isFalse",
+				"return max(t0, t1, t2) == isTrue",
+			},
+		},
+		{
+			name:     "Error case - unknown field",
+			typeName: "Account",
+			query:    `email=x || brand=nike`,
+			wantErr:  "attribute brand: brand is not an exported field",
+		},
+		{
+			name:     "Error case - unexported field",
+			typeName: "Account",
+			query:    `secret is missing`,
+			wantErr:  "attribute secret: secret is not an exported field",
+		},
+		{
+			name:     "Error case - aggregate",
+			typeName: "Account",
+			query:    `count(tags) > 1`,
+			wantErr:  "attribute count(tags): aggregates are not supported",
+		},
+		{
+			name:     "Error case - invalid literal",
+			typeName: "Account",
+			query:    `id>abc`,
+			wantErr:  `unable to parse "abc" as integer`,
+		},
+		{
+			name:     "Error case - unsupported type",
+			typeName: "Account",
+			query:    `tags=gold`,
+			wantErr:  "unsupported type",
+		},
+		{
+			name:     "Error case - unknown type",
+			typeName: "Member",
+			query:    `id=1`,
+			wantErr:  "struct type Member not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg, err := loadPackage(dir)
+			if err != nil {
+				t.Fatalf("loadPackage() error = %v", err)
+			}
+			g, err := newGenerator(pkg, tt.typeName, "Match"+tt.typeName, tt.query)
+			var source []byte
+			if err == nil {
+				source, err = g.generate()
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("generate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+			if strings.Contains(string(source), importPath) {
+				t.Errorf("generate() = %s, want no import of %s", source, importPath)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(source), want) {
+					t.Errorf("generate() = %s, want to contain %q", source, want)
+				}
+			}
+			if _, err := g.generateTest(); err != nil {
+				t.Errorf("generateTest() error = %v", err)
+			}
+		})
+	}
+}
+
+func Test_readRules(t *testing.T) {
+	got := readRules("# comment\nid=1\n\n&& name=budi\n")
+	if got != "id=1 && name=budi" {
+		t.Errorf("readRules() = %q, want %q", got, "id=1 && name=budi")
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		typeName = flag.String("type", "", "target struct type")
+		query    = flag.String("query", "", "condition query")
+		rules    = flag.String("rules", "", "file containing the condition query, lines starting with # are ignored")
+		funcName = flag.String("func", "", "generated function name, default Match<type>")
+		output   = flag.String("output", "", "output file, default <type>_validator.go")
+		dir      = flag.String("dir", ".", "package directory containing the target type")
+		withTest = flag.Bool("test", false, "also generate a test cross-checking the function against Condition.Validate")
+	)
+	flag.Parse()
+
+	if err := run(*typeName, *query, *rules, *funcName, *output, *dir, *withTest); err != nil {
+		fmt.Fprintln(os.Stderr, "astvalidator-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(typeName, query, rules, funcName, output, dir string, withTest bool) error {
+	if typeName == "" {
+		return fmt.Errorf("-type is required")
+	}
+	if rules != "" {
+		content, err := os.ReadFile(rules)
+		if err != nil {
+			return err
+		}
+		query = readRules(string(content))
+	}
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("-query or -rules is required")
+	}
+	if funcName == "" {
+		funcName = "Match" + typeName
+	}
+	if output == "" {
+		output = strings.ToLower(typeName) + "_validator.go"
+	}
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(dir, output)
+	}
+
+	pkg, err := loadPackage(dir)
+	if err != nil {
+		return err
+	}
+	g, err := newGenerator(pkg, typeName, funcName, query)
+	if err != nil {
+		return err
+	}
+	source, err := g.generate()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(output, source, 0644); err != nil {
+		return err
+	}
+	if !withTest {
+		return nil
+	}
+	testSource, err := g.generateTest()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(strings.TrimSuffix(output, ".go")+"_test.go", testSource, 0644)
+}
+
+func readRules(content string) string {
+	lines := []string{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, " ")
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahmadrezamusthafa/astvalidator"
+	"github.com/ahmadrezamusthafa/astvalidator/internal/valuetype"
+)
+
+const sampleCount = 1000
+
+func (g *generator) generateTest() ([]byte, error) {
+	imports := map[string]bool{"math/rand": true, "testing": true, importPath: true}
+	setters := &bytes.Buffer{}
+	allocated := map[string]bool{}
+	intermediates := []string{}
+	isIntermediate := map[string]bool{}
+	for _, field := range g.paths {
+		for _, step := range field.pointers {
+			if field.value != "*"+step.expr {
+				isIntermediate[step.expr] = true
+			}
+		}
+	}
+	for _, path := range g.order {
+		field := g.paths[path]
+		candidates := g.candidates(field)
+		for _, candidate := range candidates {
+			if strings.HasPrefix(candidate, "time.") {
+				imports["time"] = true
+			}
+		}
+		last := len(field.pointers) - 1
+		isPointer := last >= 0 && field.value == "*"+field.pointers[last].expr
+		if isPointer && isIntermediate[field.pointers[last].expr] {
+			continue
+		}
+		for i, step := range field.pointers {
+			if (isPointer && i == last) || allocated[step.expr] {
+				continue
+			}
+			allocated[step.expr] = true
+			intermediates = append(intermediates, step.expr)
+			fmt.Fprintf(setters, "%s = &%s{}\n", step.expr, step.elemType)
+		}
+		values := fmt.Sprintf("[]%s{%s}", field.goType, strings.Join(candidates, ", "))
+		if isPointer {
+			fmt.Fprintf(setters, "if i := r.Intn(%d); i < %d {\nvalue := %s[i]\n%s = &value\n}\n",
+				len(candidates)+1, len(candidates), values, field.pointers[last].expr)
+			continue
+		}
+		fmt.Fprintf(setters, "%s = %s[r.Intn(%d)]\n", field.value, values, len(candidates))
+	}
+	for i := len(intermediates) - 1; i >= 0; i-- {
+		fmt.Fprintf(setters, "if r.Intn(5) == 0 {\n%s = nil\n}\n", intermediates[i])
+	}
+
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, "// Code generated by astvalidator-gen. DO NOT EDIT.\n\npackage %s\n\n", g.pkg.name)
+	g.writeImports(buffer, imports)
+	fmt.Fprintf(buffer, "func Test%s(t *testing.T) {\n", g.funcName)
+	fmt.Fprintf(buffer, "condition, err := astvalidator.GenerateCondition(%s)\n", strconv.Quote(g.query))
+	buffer.WriteString("if err != nil {\nt.Fatalf(\"GenerateCondition() error = %v\", err)\n}\n")
+	fmt.Fprintf(buffer, "if %s(nil) {\nt.Errorf(\"%s(nil) = true, want false\")\n}\n", g.funcName, g.funcName)
+	buffer.WriteString("r := rand.New(rand.NewSource(1))\n")
+	fmt.Fprintf(buffer, "for n := 0; n < %d; n++ {\n", sampleCount)
+	fmt.Fprintf(buffer, "%s := &%s{}\n", g.param, g.typeName)
+	buffer.Write(setters.Bytes())
+	fmt.Fprintf(buffer, "want, err := condition.Validate(%s)\n", g.param)
+	buffer.WriteString("if err != nil {\nt.Fatalf(\"Condition.Validate() error = %v\", err)\n}\n")
+	fmt.Fprintf(buffer, "if got := %s(%s); got != want {\n", g.funcName, g.param)
+	fmt.Fprintf(buffer, "t.Errorf(\"%s(%%+v) = %%v, Condition.Validate() = %%v\", *%s, got, want)\n}\n}\n}\n", g.funcName, g.param)
+	return format.Source(buffer.Bytes())
+}
+
+func (g *generator) candidates(field *fieldPath) []string {
+	candidates := []string{}
+	seen := map[string]bool{}
+	add := func(candidate string) {
+		if !seen[candidate] {
+			seen[candidate] = true
+			candidates = append(candidates, candidate)
+		}
+	}
+	for _, literal := range g.literals[field.path] {
+		switch field.kind {
+		case "int", "uint":
+			value, _ := strconv.ParseInt(literal, 10, 64)
+			for _, candidate := range []int64{value - 1, value, value + 1} {
+				if fitsInteger(field, candidate) {
+					add(strconv.FormatInt(candidate, 10))
+				}
+			}
+		case "float":
+			value, _ := strconv.ParseFloat(literal, 64)
+			for _, candidate := range []float64{value - 1, value, value + 1} {
+				add(formatFloat(candidate))
+			}
+		case "bool":
+			add("true")
+			add("false")
+		case "time":
+			value, _ := time.Parse(astvalidator.DateTimeFormat, literal)
+			for _, candidate := range []time.Time{value.Add(-time.Second), value, value.Add(time.Second)} {
+				add(timeLiteral(candidate))
+			}
+		case "string":
+			add(strconv.Quote(literal))
+			add(strconv.Quote(literal + "x"))
+			add(`""`)
+			switch valuetype.Of(literal) {
+			case valuetype.Numeric:
+				value, _ := strconv.ParseFloat(literal, 64)
+				add(strconv.Quote(strconv.FormatFloat(value-1, 'f', -1, 64)))
+				add(strconv.Quote(strconv.FormatFloat(value+1, 'f', -1, 64)))
+			case valuetype.Time:
+				value, _ := time.Parse(astvalidator.DateTimeFormat, literal)
+				add(strconv.Quote(value.Add(-time.Hour).Format(astvalidator.DateTimeFormat)))
+				add(strconv.Quote(value.Add(time.Hour).Format(astvalidator.DateTimeFormat)))
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		add(fmt.Sprintf("*new(%s)", field.goType))
+	}
+	return candidates
+}
+
+func fitsInteger(field *fieldPath, value int64) bool {
+	bitSize, ok := mapBitSize[field.goType]
+	if !ok {
+		bitSize = 64
+	}
+	if field.kind == "uint" {
+		return value >= 0 && (bitSize == 64 || value < 1<<uint(bitSize))
+	}
+	return bitSize == 64 || (value >= -(1<<uint(bitSize-1)) && value < 1<<uint(bitSize-1))
+}
+
+func timeLiteral(value time.Time) string {
+	return fmt.Sprintf("time.Date(%d, %d, %d, %d, %d, %d, 0, time.UTC)",
+		value.Year(), value.Month(), value.Day(), value.Hour(), value.Minute(), value.Second())
+}
@@ -0,0 +1,449 @@
+package astvalidator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Program struct {
+	condition Condition
+	rType     reflect.Type
+	evaluate  evaluator
+}
+
+type CompileErrors []error
+
+type evaluator func(e *evaluation, rValue reflect.Value) (Truth, error)
+
+type matcher func(field reflect.Value) (bool, error)
+
+type programCache struct {
+	evaluators sync.Map
+}
+
+type compiledProgram struct {
+	operator   string
+	attribute  *Attribute
+	conditions []*Condition
+	evaluate   evaluator
+}
+
+func CompileFor(query string, rType reflect.Type, opts ...Option) (*Program, error) {
+	if rType == nil {
+		return nil, ErrNilData
+	}
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	if rType.Kind() != reflect.Struct {
+		return nil, newUnsupportedTypeError("struct", rType)
+	}
+	condition, err := GenerateCondition(query, opts...)
+	if err != nil {
+		return nil, err
+	}
+	errs := CompileErrors{}
+	program := &Program{
+		condition: condition,
+		rType:     rType,
+		evaluate:  compileCondition(&condition, getStructPlan(rType), &errs),
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return program, nil
+}
+
+func (c *Condition) compiledFor(rType reflect.Type) evaluator {
+	if program, ok := c.programs.evaluators.Load(rType); ok && program.(*compiledProgram).matches(c) {
+		return program.(*compiledProgram).evaluate
+	}
+	program := &compiledProgram{
+		operator:   c.Operator,
+		attribute:  c.Attribute,
+		conditions: c.Conditions,
+		evaluate:   compileCondition(c, getStructPlan(rType), nil),
+	}
+	c.programs.evaluators.Store(rType, program)
+	return program.evaluate
+}
+
+func (p *compiledProgram) matches(c *Condition) bool {
+	if p.attribute != c.Attribute || p.operator != c.Operator || len(p.conditions) != len(c.Conditions) {
+		return false
+	}
+	return len(c.Conditions) == 0 || &p.conditions[0] == &c.Conditions[0]
+}
+
+func structValue(data interface{}) (reflect.Value, bool) {
+	if _, ok := data.(AttributeResolver); ok || data == nil {
+		return reflect.Value{}, false
+	}
+	rValue := reflect.ValueOf(data)
+	for rValue.Kind() == reflect.Ptr {
+		if rValue.IsNil() {
+			return reflect.Value{}, false
+		}
+		rValue = rValue.Elem()
+	}
+	return rValue, rValue.Kind() == reflect.Struct
+}
+
+func compileCondition(c *Condition, plan *structPlan, errs *CompileErrors) evaluator {
+	if len(c.Conditions) == 0 {
+		return compileAttribute(c.Attribute, plan, errs)
+	}
+	evaluators := make([]evaluator, len(c.Conditions))
+	operators := make([]string, len(c.Conditions))
+	leaves := make([]int, len(c.Conditions))
+	for i, subCondition := range c.Conditions {
+		evaluators[i] = compileCondition(subCondition, plan, errs)
+		operators[i] = subCondition.Operator
+		leaves[i] = subCondition.countLeaves()
+	}
+	return func(e *evaluation, rValue reflect.Value) (Truth, error) {
+		truth, err := evaluators[0](e, rValue)
+		if err != nil {
+			return TruthFalse, err
+		}
+		for i := 1; i < len(evaluators); i++ {
+			if !e.exhaustive && truth.isDecided(operators[i]) {
+				e.stats.LeavesShortCircuited += leaves[i]
+				continue
+			}
+			subTruth, err := evaluators[i](e, rValue)
+			if err != nil {
+				return TruthFalse, err
+			}
+			if operators[i] == LogicalOperatorOr {
+				truth = truth.Or(subTruth)
+			} else {
+				truth = truth.And(subTruth)
+			}
+		}
+		return truth, nil
+	}
+}
+
+func compileAttribute(attribute *Attribute, plan *structPlan, errs *CompileErrors) evaluator {
+	if attribute == nil || attribute.Name == "" {
+		return func(e *evaluation, rValue reflect.Value) (Truth, error) {
+			return e.compareAttribute(attribute)
+		}
+	}
+	if function, path, ok := parseAggregate(attribute.Name); ok {
+		if problem := plan.checkAggregate(function, path, attribute); problem != nil && errs != nil {
+			*errs = append(*errs, problem)
+		}
+		return func(e *evaluation, rValue reflect.Value) (Truth, error) {
+			return e.compareAttribute(attribute)
+		}
+	}
+	field, ok := plan.fields[attribute.Name]
+	if !ok && errs != nil && !plan.hasPath(attribute.Name) {
+		*errs = append(*errs, &UnknownAttributeError{Attribute: attribute.Name})
+	}
+	switch {
+	case !ok, field.compare == nil, attribute.Operator == OperatorIsMissing, attribute.Operator == OperatorIsNotMissing:
+		return func(e *evaluation, rValue reflect.Value) (Truth, error) {
+			return e.compareAttribute(attribute)
+		}
+	}
+
+	match, problem := compileMatcher(field.rType, attribute)
+	if problem != nil && errs != nil {
+		var lenient *lenientError
+		if errors.As(problem, &lenient) {
+			problem = lenient.err
+		}
+		*errs = append(*errs, problem)
+	}
+	if match == nil {
+		return func(e *evaluation, rValue reflect.Value) (Truth, error) {
+			if err := e.ctx.Err(); err != nil {
+				return TruthFalse, err
+			}
+			e.stats.LeavesVisited++
+			return e.truth(false, problem)
+		}
+	}
+	index := field.index
+	if len(index) == 1 {
+		i := index[0]
+		return func(e *evaluation, rValue reflect.Value) (Truth, error) {
+			if err := e.ctx.Err(); err != nil {
+				return TruthFalse, err
+			}
+			e.stats.LeavesVisited++
+			return e.truth(match(rValue.Field(i)))
+		}
+	}
+	return func(e *evaluation, rValue reflect.Value) (Truth, error) {
+		if err := e.ctx.Err(); err != nil {
+			return TruthFalse, err
+		}
+		e.stats.LeavesVisited++
+		return e.truth(match(rValue.FieldByIndex(index)))
+	}
+}
+
+func compileMatcher(rType reflect.Type, attribute *Attribute) (matcher, error) {
+	if rType.Kind() == reflect.Ptr {
+		match, problem := compileMatcher(rType.Elem(), attribute)
+		if match == nil {
+			return nil, problem
+		}
+		return func(field reflect.Value) (bool, error) {
+			if field.IsNil() {
+				return false, errNullValue
+			}
+			return match(field.Elem())
+		}, problem
+	}
+	switch rType {
+	case timeType:
+		compare, err := compileTimeComparator(attribute)
+		if err != nil {
+			return nil, err
+		}
+		return func(field reflect.Value) (bool, error) {
+			if field.CanAddr() {
+				return compare(*field.Addr().Interface().(*time.Time)), nil
+			}
+			value, ok := field.Interface().(time.Time)
+			return ok && compare(value), nil
+		}, nil
+	case jsonNumberType:
+		if _, err := strconv.ParseFloat(attribute.Value, 64); err != nil {
+			return nil, newParseError(attribute, "number", err)
+		}
+		return func(field reflect.Value) (bool, error) {
+			return compareJSONNumber(field, attribute)
+		}, nil
+	}
+
+	switch rType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		conditionValue, err := strconv.ParseInt(attribute.Value, 10, 64)
+		if err != nil {
+			return nil, newParseError(attribute, "integer", err)
+		}
+		compare := integerComparator(attribute.Operator, conditionValue)
+		return func(field reflect.Value) (bool, error) {
+			return compare(field.Int()), nil
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		conditionValue, err := strconv.ParseInt(attribute.Value, 10, 64)
+		if err != nil {
+			return nil, newParseError(attribute, "integer", err)
+		}
+		if attribute.Operator == OperatorEqual {
+			return func(field reflect.Value) (bool, error) {
+				return conditionValue >= 0 && field.Uint() == uint64(conditionValue), nil
+			}, nil
+		}
+		compare := floatComparator(attribute.Operator, float64(conditionValue))
+		return func(field reflect.Value) (bool, error) {
+			return compare(float64(field.Uint())), nil
+		}, nil
+	case reflect.Float32, reflect.Float64:
+		conditionValue, err := strconv.ParseFloat(attribute.Value, 64)
+		if err != nil {
+			return nil, newParseError(attribute, "float", err)
+		}
+		compare := floatComparator(attribute.Operator, conditionValue)
+		return func(field reflect.Value) (bool, error) {
+			return compare(field.Float()), nil
+		}, nil
+	case reflect.Bool:
+		if attribute.Operator != OperatorEqual {
+			return nil, newIncomparableError(attribute, "numeric or time", "bool")
+		}
+		var problem error
+		switch attribute.Value {
+		case "t", "true", "f", "false":
+		default:
+			problem = newParseError(attribute, "bool", strconv.ErrSyntax)
+		}
+		conditionValue := stringToBool(attribute.Value)
+		return func(field reflect.Value) (bool, error) {
+			return field.Bool() == conditionValue, nil
+		}, problem
+	case reflect.String:
+		return compileStringMatcher(attribute)
+	case reflect.Interface:
+		return func(field reflect.Value) (bool, error) {
+			return compareDynamic(field, attribute)
+		}, nil
+	default:
+		var problem error
+		if attribute.Operator != OperatorEqual {
+			problem = newIncomparableError(attribute, "comparable value", rType.String())
+		}
+		return func(field reflect.Value) (bool, error) {
+			return compareInterface(field, attribute)
+		}, problem
+	}
+}
+
+func compileStringMatcher(attribute *Attribute) (matcher, error) {
+	if attribute.Operator == OperatorEqual {
+		conditionValue := attribute.Value
+		return func(field reflect.Value) (bool, error) {
+			return field.String() == conditionValue, nil
+		}, nil
+	}
+	switch conditionValueType(attribute.Value) {
+	case TypeTime:
+		compare, err := compileTimeComparator(attribute)
+		if err != nil {
+			return nil, err
+		}
+		return func(field reflect.Value) (bool, error) {
+			value, err := time.Parse(DateTimeFormat, field.String())
+			if err != nil {
+				return false, newIncomparableError(attribute, "time", "string")
+			}
+			return compare(value), nil
+		}, nil
+	case TypeNumeric:
+		compare := floatComparator(attribute.Operator, stringToFloat64(attribute.Value))
+		return func(field reflect.Value) (bool, error) {
+			value, err := strconv.ParseFloat(field.String(), 64)
+			if err != nil {
+				return false, newIncomparableError(attribute, "numeric", "string")
+			}
+			return compare(value), nil
+		}, nil
+	default:
+		return nil, newIncomparableError(attribute, "numeric or time", "alphanumeric")
+	}
+}
+
+func integerComparator(operator string, conditionValue int64) func(value int64) bool {
+	switch operator {
+	case OperatorEqual:
+		return func(value int64) bool { return value == conditionValue }
+	case OperatorGreaterThan:
+		return func(value int64) bool { return value > conditionValue }
+	case OperatorLessThan:
+		return func(value int64) bool { return value < conditionValue }
+	case OperatorGreaterThanEqual:
+		return func(value int64) bool { return value >= conditionValue }
+	default:
+		return func(value int64) bool { return value <= conditionValue }
+	}
+}
+
+func floatComparator(operator string, conditionValue float64) func(value float64) bool {
+	switch operator {
+	case OperatorEqual:
+		return func(value float64) bool { return value == conditionValue }
+	case OperatorGreaterThan:
+		return func(value float64) bool { return value > conditionValue }
+	case OperatorLessThan:
+		return func(value float64) bool { return value < conditionValue }
+	case OperatorGreaterThanEqual:
+		return func(value float64) bool { return value >= conditionValue }
+	default:
+		return func(value float64) bool { return value <= conditionValue }
+	}
+}
+
+func compileTimeComparator(attribute *Attribute) (func(value time.Time) bool, error) {
+	conditionValue, err := parseTimeValue(attribute.Value)
+	if err != nil {
+		return nil, newParseError(attribute, "time", err)
+	}
+	if !isRelativeTime(attribute.Value) {
+		return timeComparator(attribute.Operator, conditionValue), nil
+	}
+	operator, relative := attribute.Operator, attribute.Value
+	return func(value time.Time) bool {
+		conditionValue, _ := parseTimeValue(relative)
+		if operator == OperatorEqual {
+			return value.Equal(conditionValue)
+		}
+		return validateTime(value, operator, conditionValue)
+	}, nil
+}
+
+func timeComparator(operator string, conditionValue time.Time) func(value time.Time) bool {
+	switch operator {
+	case OperatorEqual:
+		return conditionValue.Equal
+	case OperatorGreaterThan:
+		return conditionValue.Before
+	case OperatorLessThan:
+		return conditionValue.After
+	case OperatorGreaterThanEqual:
+		return func(value time.Time) bool { return !value.Before(conditionValue) }
+	default:
+		return func(value time.Time) bool { return !value.After(conditionValue) }
+	}
+}
+
+func (p *Program) Validate(data interface{}, opts ...Option) (bool, error) {
+	return p.ValidateContext(context.Background(), data, opts...)
+}
+
+func (p *Program) ValidateContext(ctx context.Context, data interface{}, opts ...Option) (bool, error) {
+	rValue, err := p.bind(data)
+	if err != nil {
+		return false, err
+	}
+	return p.validateValue(ctx, rValue, opts)
+}
+
+func (p *Program) validateValue(ctx context.Context, rValue reflect.Value, opts []Option) (bool, error) {
+	e := p.condition.acquireEvaluation(ctx, rValue, opts)
+	defer e.release()
+	truth, err := p.evaluate(e, rValue)
+	if err != nil {
+		return false, err
+	}
+	return e.result(truth)
+}
+
+func (p *Program) bind(data interface{}) (reflect.Value, error) {
+	if data == nil {
+		return reflect.Value{}, ErrNilData
+	}
+	rValue := reflect.ValueOf(data)
+	for rValue.Kind() == reflect.Ptr {
+		if rValue.IsNil() {
+			return reflect.Value{}, ErrNilPointer
+		}
+		rValue = rValue.Elem()
+	}
+	if rValue.Type() != p.rType {
+		return reflect.Value{}, newUnsupportedTypeError(p.rType.String(), rValue.Type())
+	}
+	return rValue, nil
+}
+
+func (e CompileErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e CompileErrors) Unwrap() []error {
+	return e
+}
+
+func (e CompileErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package astvalidator
+
+import (
+	"reflect"
+	"testing"
+)
+
+//BENCHMARK Program Validate
+//Improvement history:
+//------------------------------------
+//	attempt	   |  time per loop
+//------------------------------------
+//  3505786	      371 ns/op
+//  9472522	      151 ns/op (now)
+//------------------------------------
+func BenchmarkProgramValidate(b *testing.B) {
+	type Object struct {
+		ID       string `json:"id"`
+		MemberID string `json:"member_id"`
+		Division string `json:"division"`
+	}
+	object := Object{
+		ID:       "1",
+		MemberID: "2",
+		Division: "finance",
+	}
+
+	query := "(id=1 && (member_id=12||member_id=2))  &&   (division=engineering || division=finance)"
+	program, _ := CompileFor(query, reflect.TypeOf(object))
+	for n := 0; n < b.N; n++ {
+		program.Validate(object)
+	}
+}
+
+//BENCHMARK Predicate Filter
+//Improvement history:
+//------------------------------------
+//	attempt	   |  time per loop
+//------------------------------------
+//  3229	      350623 ns/op
+//  5790	      253810 ns/op
+//  10000	      141957 ns/op (now)
+//------------------------------------
+func BenchmarkPredicateFilter(b *testing.B) {
+	type Account struct {
+		ID       int     `json:"id"`
+		MemberID int     `json:"member_id"`
+		Division string  `json:"division"`
+		Score    int     `json:"score"`
+		Money    float64 `json:"money"`
+	}
+	divisions := []string{"engineering", "finance", "people", "business"}
+	accounts := make([]Account, 1000)
+	for i := range accounts {
+		accounts[i] = Account{
+			ID:       i,
+			MemberID: i % 50,
+			Division: divisions[i%len(divisions)],
+			Score:    i % 100,
+			Money:    float64(i * 1000),
+		}
+	}
+
+	query := "(division=engineering || division=finance) && score>=50 && money<500000"
+	predicate, _ := Compile[Account](query)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		predicate.Filter(accounts)
+	}
+}
@@ -0,0 +1,405 @@
+package astvalidator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCompileFor(t *testing.T) {
+	type Profile struct {
+		City string `json:"city"`
+	}
+	type Item struct {
+		Price int `json:"price"`
+	}
+	type Member struct {
+		ID       int               `json:"id"`
+		Score    float64           `json:"score"`
+		Active   bool              `json:"active"`
+		Name     string            `json:"name"`
+		JoinDate time.Time         `json:"join_date"`
+		Email    *string           `json:"email"`
+		Profile  Profile           `json:"profile"`
+		Deleted  *time.Time        `json:"deleted"`
+		Items    []Item            `json:"items"`
+		Tags     map[string]string `json:"tags"`
+		Extra    interface{}       `json:"extra"`
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		rType     reflect.Type
+		wantCount int
+		wantErrIs error
+		wantErrAs interface{}
+	}{
+		{
+			name:  "Normal case - valid query",
+			query: `id=1 && score>=7.5 && active=true && name=budi && join_date>"2015-01-01 00:00:00" && profile.city=jakarta && email is missing`,
+			rType: reflect.TypeOf(Member{}),
+		},
+		{
+			name:  "Normal case - pointer type",
+			query: `id>1 && deleted<"2020-01-01 00:00:00"`,
+			rType: reflect.TypeOf(&Member{}),
+		},
+		{
+			name:  "Normal case - map keys and slice indexes",
+			query: `items.0.price>10 && tags.tier=gold && extra.level=2`,
+			rType: reflect.TypeOf(Member{}),
+		},
+		{
+			name:      "Error case - invalid map keys and slice indexes",
+			query:     `items.first.price>10 || items.0.cost>10 || tags.tier.name=gold`,
+			rType:     reflect.TypeOf(Member{}),
+			wantCount: 3,
+			wantErrIs: ErrUnknownAttribute,
+		},
+		{
+			name:      "Error case - unknown attributes",
+			query:     `id=1 && brand=nike || profile.country=id`,
+			rType:     reflect.TypeOf(Member{}),
+			wantCount: 2,
+			wantErrIs: ErrUnknownAttribute,
+		},
+		{
+			name:      "Error case - every literal problem is reported",
+			query:     `id=abc && score>high && join_date<yesterday && active=yes && active>1`,
+			rType:     reflect.TypeOf(Member{}),
+			wantCount: 5,
+			wantErrAs: new(*ParseError),
+		},
+		{
+			name:      "Error case - ordering on alphanumeric literal",
+			query:     `name>budi`,
+			rType:     reflect.TypeOf(Member{}),
+			wantCount: 1,
+			wantErrAs: new(*TypeMismatchError),
+		},
+		{
+			name:      "Error case - not a struct",
+			query:     `id=1`,
+			rType:     reflect.TypeOf(map[string]interface{}{}),
+			wantErrIs: ErrUnsupportedType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := CompileFor(tt.query, tt.rType)
+			if tt.wantCount == 0 && tt.wantErrIs == nil {
+				if err != nil || program == nil {
+					t.Errorf("CompileFor() error = %v, want program", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("CompileFor() error = nil, want error")
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("CompileFor() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if tt.wantErrAs != nil && !errors.As(err, tt.wantErrAs) {
+				t.Errorf("CompileFor() error = %v, want %T", err, tt.wantErrAs)
+			}
+			if tt.wantCount > 0 {
+				var errs CompileErrors
+				if !errors.As(err, &errs) || len(errs) != tt.wantCount {
+					t.Errorf("CompileFor() errors = %v, want %d errors", err, tt.wantCount)
+				}
+			}
+		})
+	}
+}
+
+func TestProgram_Validate(t *testing.T) {
+	type Member struct {
+		ID       int       `json:"id"`
+		Name     string    `json:"name"`
+		Active   bool      `json:"active"`
+		JoinDate time.Time `json:"join_date"`
+		Email    *string   `json:"email"`
+		Points   Provider  `json:"points"`
+		Scores   []int     `json:"scores"`
+	}
+	email := "budi@mail.com"
+	member := Member{
+		ID:       1,
+		Name:     "budi",
+		Active:   true,
+		JoinDate: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+		Points: func(ctx context.Context) (interface{}, error) {
+			return 120, nil
+		},
+		Scores: []int{80, 95},
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		data        interface{}
+		opts        []Option
+		wantIsValid bool
+		wantErr     bool
+	}{
+		{
+			name:        "Normal case - valid",
+			query:       `id=1 && (name=andi || active=true) && join_date>"2015-01-01 00:00:00"`,
+			data:        member,
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - invalid",
+			query:       `id=1 && join_date<"2015-01-01 00:00:00"`,
+			data:        &member,
+			wantIsValid: false,
+		},
+		{
+			name:        "Normal case - provider",
+			query:       `points>=100`,
+			data:        member,
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - slice index",
+			query:       `scores.1>90 && scores.0<90`,
+			data:        member,
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - null is unknown",
+			query:       `id=1 && email=budi@mail.com`,
+			data:        member,
+			opts:        []Option{WithUnknownAs(UnknownAsTrue)},
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - missing predicate",
+			query:       `email is not missing`,
+			data:        Member{Email: &email},
+			wantIsValid: true,
+		},
+		{
+			name:    "Error case - another type",
+			query:   `id=1`,
+			data:    struct{ ID int }{ID: 1},
+			wantErr: true,
+		},
+		{
+			name:    "Error case - nil pointer",
+			query:   `id=1`,
+			data:    (*Member)(nil),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := CompileFor(tt.query, reflect.TypeOf(Member{}))
+			if err != nil {
+				t.Fatalf("CompileFor() error = %v", err)
+			}
+			gotIsValid, err := program.Validate(tt.data, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Program.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotIsValid != tt.wantIsValid {
+				t.Errorf("Program.Validate() = %v, want %v", gotIsValid, tt.wantIsValid)
+			}
+			if tt.wantErr {
+				return
+			}
+			condition, _ := GenerateCondition(tt.query)
+			if isValid, _ := condition.Validate(tt.data, tt.opts...); isValid != gotIsValid {
+				t.Errorf("Program.Validate() = %v, Condition.Validate() = %v", gotIsValid, isValid)
+			}
+		})
+	}
+}
+
+func TestCondition_ValidateCompiled(t *testing.T) {
+	type Profile struct {
+		City string `json:"city"`
+	}
+	type Member struct {
+		ID       int               `json:"id"`
+		Level    uint8             `json:"level"`
+		Score    float64           `json:"score"`
+		Active   bool              `json:"active"`
+		Name     string            `json:"name"`
+		Code     string            `json:"code"`
+		Since    string            `json:"since"`
+		JoinDate time.Time         `json:"join_date"`
+		Email    *string           `json:"email"`
+		Age      *int              `json:"age"`
+		Profile  Profile           `json:"profile"`
+		Manager  *Profile          `json:"manager"`
+		Tags     map[string]string `json:"tags"`
+		Extra    interface{}       `json:"extra"`
+	}
+	age := 30
+	member := Member{
+		ID:       7,
+		Level:    3,
+		Score:    7.5,
+		Active:   true,
+		Name:     "budi",
+		Code:     "120",
+		Since:    "2015-01-01 00:00:00",
+		JoinDate: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+		Age:      &age,
+		Profile:  Profile{City: "jakarta"},
+		Manager:  &Profile{City: "bandung"},
+		Tags:     map[string]string{"tier": "gold"},
+		Extra:    "x",
+	}
+
+	queries := []string{
+		`id=7 && level>=3 && score<8 && active=true`,
+		`id>7 || level<3 || score>=7.6 || active=false`,
+		`name=budi && code>100 && since<"2016-01-01 00:00:00"`,
+		`join_date>="2016-01-01 00:00:00" && join_date<="2016-01-01 00:00:00"`,
+		`join_date="2016-01-01 00:00:00" && join_date>"2017-01-01 00:00:00"`,
+		`email=x || age>=30`,
+		`email=x && age>=30`,
+		`profile.city=jakarta && manager.city=bandung && tags.tier=gold`,
+		`brand=nike || (name=budi && id<=7)`,
+		`email is missing && age is not missing && brand is missing`,
+		`extra=x && active>1`,
+		`name>budi || id=7`,
+		`code>abc || id=7`,
+		`id=abc`,
+		`level=-1 || level=3`,
+		`join_date<now && since<now-1w && join_date>now-1d`,
+		`since>now+1h || join_date=now`,
+	}
+	for _, query := range queries {
+		for _, mode := range []Mode{ModeLenient, ModeStrict} {
+			condition, _ := GenerateCondition(query)
+			gotIsValid, gotErr := condition.Validate(member, WithMode(mode))
+			wantIsValid, _, wantErr := condition.ValidateWithTrace(member, WithMode(mode))
+			if gotIsValid != wantIsValid || (gotErr != nil) != (wantErr != nil) {
+				t.Errorf("Condition.Validate(%q, %v) = %v, %v, want %v, %v", query, mode, gotIsValid, gotErr, wantIsValid, wantErr)
+			}
+		}
+	}
+}
+
+func TestCondition_ValidateCompiledMutation(t *testing.T) {
+	type Member struct {
+		ID       int    `json:"id"`
+		Division string `json:"division"`
+	}
+	member := Member{ID: 7, Division: "finance"}
+
+	condition, _ := GenerateCondition("id=7 && division=finance")
+	if isValid, _ := condition.Validate(member); !isValid {
+		t.Fatalf("Condition.Validate() = %v, want true", isValid)
+	}
+	condition.Conditions = append([]*Condition{{Attribute: &Attribute{Name: "id", Operator: "=", Value: "8"}}}, condition.Conditions[1:]...)
+	if isValid, _ := condition.Validate(member); isValid {
+		t.Errorf("Condition.Validate() after replacing the conditions = %v, want false", isValid)
+	}
+	condition.Conditions = condition.Conditions[1:]
+	if isValid, _ := condition.Validate(member); !isValid {
+		t.Errorf("Condition.Validate() after removing a condition = %v, want true", isValid)
+	}
+
+	copied := condition
+	copied.Conditions = []*Condition{{Attribute: &Attribute{Name: "division", Operator: "=", Value: "engineering"}}}
+	if isValid, _ := copied.Validate(member); isValid {
+		t.Errorf("copied Condition.Validate() = %v, want false", isValid)
+	}
+	if isValid, _ := condition.Validate(member); !isValid {
+		t.Errorf("Condition.Validate() after validating a copy = %v, want true", isValid)
+	}
+}
+
+func TestCondition_ValidateAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not measured with the race detector")
+	}
+	type Profile struct {
+		Level int `json:"level"`
+	}
+	type Member struct {
+		ID       int       `json:"id"`
+		Points   uint32    `json:"points"`
+		Score    float64   `json:"score"`
+		Active   bool      `json:"active"`
+		Name     string    `json:"name"`
+		Code     string    `json:"code"`
+		JoinDate time.Time `json:"join_date"`
+		Age      *int      `json:"age"`
+		Profile  Profile   `json:"profile"`
+	}
+	age := 30
+	members := make([]Member, 1000)
+	for i := range members {
+		members[i] = Member{
+			ID:       i,
+			Points:   uint32(i * 10),
+			Score:    float64(i % 100),
+			Active:   i%2 == 0,
+			Name:     "budi",
+			Code:     "120",
+			JoinDate: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC),
+			Age:      &age,
+			Profile:  Profile{Level: i % 5},
+		}
+	}
+	query := `id>=0 && points>=0 && score<100 && (active=true || active=false) && name=budi && code>100 && join_date>"2015-01-01 00:00:00" && age>=18 && profile.level<5`
+	condition, _ := GenerateCondition(query)
+	predicate, _ := Compile[Member](query)
+
+	tests := []struct {
+		name string
+		run  func(members []Member)
+	}{
+		{
+			name: "Validate",
+			run: func(members []Member) {
+				for i := range members {
+					condition.Validate(&members[i])
+				}
+			},
+		},
+		{
+			name: "FilterSlice",
+			run: func(members []Member) {
+				condition.FilterSlice(members)
+			},
+		},
+		{
+			name: "Predicate Filter",
+			run: func(members []Member) {
+				predicate.Filter(members)
+			},
+		},
+		{
+			name: "Predicate Count",
+			run: func(members []Member) {
+				predicate.Count(members)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if isValid, _ := condition.Validate(members[0]); !isValid {
+				t.Fatalf("Condition.Validate() = false, want true")
+			}
+			fewAllocs := testing.AllocsPerRun(20, func() {
+				tt.run(members[:10])
+			})
+			manyAllocs := testing.AllocsPerRun(20, func() {
+				tt.run(members)
+			})
+			if fewAllocs != manyAllocs {
+				t.Errorf("%s allocs = %v for 10 rows, %v for 1000 rows, want no allocation per row", tt.name, fewAllocs, manyAllocs)
+			}
+		})
+	}
+}
@@ -2,17 +2,12 @@ package astvalidator
 
 import (
 	"strings"
-	"time"
-)
 
-func (c *Condition) ValidateCondition(condition Condition) (isValid bool, err error) {
-	referenceAttrMap := make(map[string]bool)
-	inputAttrMap := make(map[string]bool)
+	"github.com/ahmadrezamusthafa/astvalidator/internal/valuetype"
+)
 
-	c.readAllAttributes(referenceAttrMap)
-	condition.readAllAttributes(inputAttrMap)
-	condition.setNonExistAttributeDefaultValue(referenceAttrMap, inputAttrMap)
-	return c.validateConditionAttribute(condition)
+func (c *Condition) ValidateCondition(condition Condition, opts ...Option) (isValid bool, err error) {
+	return c.resolveOptions(opts).result(c.validateConditionAttribute(condition))
 }
 
 func (c *Condition) readAllAttributes(attrMap map[string]bool) {
@@ -29,122 +24,79 @@ func (c *Condition) readAllAttributes(attrMap map[string]bool) {
 	}
 }
 
-func (c *Condition) validateConditionAttribute(condition Condition) (isValid bool, err error) {
+func (c *Condition) validateConditionAttribute(condition Condition) (truth Truth) {
 	if len(c.Conditions) > 0 {
 		for i, subCondition := range c.Conditions {
-			isSubValid, err := subCondition.validateConditionAttribute(condition)
-			if err != nil {
-				return false, err
+			if i > 0 && truth.isDecided(subCondition.Operator) {
+				continue
 			}
+			subTruth := subCondition.validateConditionAttribute(condition)
 			if i == 0 {
-				isValid = isSubValid
+				truth = subTruth
+			} else if subCondition.Operator == LogicalOperatorOr {
+				truth = truth.Or(subTruth)
 			} else {
-				if subCondition.Operator == LogicalOperatorOr {
-					isValid = isValid || isSubValid
-				} else {
-					isValid = isValid && isSubValid
-				}
+				truth = truth.And(subTruth)
 			}
 		}
-	} else {
-		isValid, _, err = c.validateConditionValue("", condition)
+		return truth
+	}
+	if c.Attribute == nil {
+		return TruthFalse
+	}
+	truth, found := c.validateConditionValue(condition)
+	switch c.Attribute.Operator {
+	case OperatorIsMissing:
+		return TruthOf(!found)
+	case OperatorIsNotMissing:
+		return TruthOf(found)
+	}
+	if !found {
+		return TruthUnknown
 	}
-	return
+	return truth
 }
 
-func (c *Condition) validateConditionValue(prefix string, condition Condition) (isValid, isSkip bool, err error) {
-	isValid = true
+func (c *Condition) validateConditionValue(condition Condition) (truth Truth, found bool) {
 	if len(condition.Conditions) > 0 {
-		for i, subCondition := range condition.Conditions {
-			isSubValid, isSkip, err := c.validateConditionValue(prefix, *subCondition)
-			if err != nil {
-				return false, false, err
+		for _, subCondition := range condition.Conditions {
+			if found && truth.isDecided(subCondition.Operator) {
+				continue
 			}
-			if isSkip {
+			subTruth, subFound := c.validateConditionValue(*subCondition)
+			if !subFound {
 				continue
 			}
-			if i == 0 {
-				isValid = isSubValid
+			if !found {
+				truth, found = subTruth, true
+			} else if subCondition.Operator == LogicalOperatorOr {
+				truth = truth.Or(subTruth)
 			} else {
-				if subCondition.Operator == LogicalOperatorOr {
-					isValid = isValid || isSubValid
-				} else {
-					isValid = isValid && isSubValid
-				}
-			}
-		}
-	} else {
-		if c.Attribute == nil || condition.Attribute == nil {
-			return false, false, nil
-		}
-		if condition.Attribute.Name == c.Attribute.Name {
-			operator := c.Attribute.Operator
-			switch operator {
-			case OperatorEqual:
-				isValid = strings.EqualFold(condition.Attribute.Value, c.Attribute.Value)
-			default:
-				value := condition.Attribute.Value
-				secondValue := c.Attribute.Value
-				valueType := getValueType(c.Attribute.Value)
-
-				switch valueType {
-				case TypeTime:
-					isValid = validateTime(stringToTime(value), operator, stringToTime(secondValue))
-				default:
-					isValid = validateNumeric(stringToFloat64(value), operator, stringToFloat64(secondValue))
-				}
+				truth = truth.And(subTruth)
 			}
-		} else {
-			return false, true, nil
 		}
+		return truth, found
 	}
-	return
-}
-
-func (c *Condition) setNonExistAttributeDefaultValue(referenceAttrMap, inputAttrMap map[string]bool) {
-	for attrName, _ := range referenceAttrMap {
-		if _, ok := inputAttrMap[attrName]; !ok {
-			c.Conditions = append(c.Conditions, &Condition{
-				Operator: LogicalOperatorAnd,
-				Attribute: &Attribute{
-					Name:     attrName,
-					Operator: "=",
-					Value:    "",
-				},
-			})
-		}
+	if condition.Attribute == nil || condition.Attribute.Name != c.Attribute.Name {
+		return TruthFalse, false
+	}
+	operator := c.Attribute.Operator
+	value := condition.Attribute.Value
+	secondValue := c.Attribute.Value
+	switch operator {
+	case OperatorIsMissing, OperatorIsNotMissing:
+		return TruthTrue, true
+	case OperatorEqual:
+		return TruthOf(strings.EqualFold(value, secondValue)), true
+	}
+	switch getValueType(secondValue) {
+	case TypeTime:
+		return TruthOf(validateTime(stringToTime(value), operator, stringToTime(secondValue))), true
+	default:
+		return TruthOf(validateNumeric(stringToFloat64(value), operator, stringToFloat64(secondValue))), true
 	}
 }
 
 func getValueType(value string) int {
-	var varType, indexVal, dotCount int = TypeAlphanumeric, 0, 0
-	for _, char := range value {
-		if char == ',' {
-			continue
-		}
-		if '0' <= char && char <= '9' {
-			if indexVal == 0 || (indexVal > 0 && dotCount == 1) {
-				varType = TypeNumeric
-			}
-		} else if char == '.' {
-			if indexVal > 0 && varType == TypeNumeric {
-				dotCount++
-				varType = TypeAlphanumeric
-			}
-			if dotCount > 1 {
-				varType = TypeAlphanumeric
-				break
-			}
-		} else {
-			varType = TypeAlphanumeric
-			break
-		}
-		indexVal++
-	}
-	if varType == TypeAlphanumeric {
-		if _, err := time.Parse(DateTimeFormat, value); err == nil {
-			varType = TypeTime
-		}
-	}
-	return varType
+	return valuetype.Of(value)
 }
@@ -7,6 +7,7 @@ func TestCondition_ValidateCondition(t *testing.T) {
 		name           string
 		referenceQuery string
 		input          string
+		opts           []Option
 		wantIsValid    bool
 		wantErr        bool
 	}{
@@ -199,6 +200,44 @@ func TestCondition_ValidateCondition(t *testing.T) {
 			wantIsValid:    true,
 			wantErr:        false,
 		},
+		{
+			name:           "Normal case - missing attribute is unknown",
+			referenceQuery: "id=1 && poin<100",
+			input:          "id=1",
+			wantIsValid:    false,
+			wantErr:        false,
+		},
+		{
+			name:           "Normal case - missing attribute is unknown as true",
+			referenceQuery: "id=1 && poin<100",
+			input:          "id=1",
+			opts:           []Option{WithUnknownAs(UnknownAsTrue)},
+			wantIsValid:    true,
+			wantErr:        false,
+		},
+		{
+			name:           "Normal case - missing attribute decided by or",
+			referenceQuery: "poin<100 || id=1",
+			input:          "id=1",
+			opts:           []Option{WithUnknownAs(UnknownAsError)},
+			wantIsValid:    true,
+			wantErr:        false,
+		},
+		{
+			name:           "Normal case - missing predicate",
+			referenceQuery: "id=1 && poin is missing && segment is not missing",
+			input:          "id=1 && segment=girl",
+			wantIsValid:    true,
+			wantErr:        false,
+		},
+		{
+			name:           "Error case - missing attribute is unknown as error",
+			referenceQuery: "id=1 && poin<100",
+			input:          "id=1",
+			opts:           []Option{WithUnknownAs(UnknownAsError)},
+			wantIsValid:    false,
+			wantErr:        true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -212,7 +251,7 @@ func TestCondition_ValidateCondition(t *testing.T) {
 				t.Errorf("Condition.ValidateCondition() input error = %v", err)
 				return
 			}
-			gotIsValid, err := referenceCondition.ValidateCondition(inputCondition)
+			gotIsValid, err := referenceCondition.ValidateCondition(inputCondition, tt.opts...)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Condition.ValidateCondition() error = %v, wantErr %v", err, tt.wantErr)
 				return
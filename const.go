@@ -1,11 +1,17 @@
 package astvalidator
 
+import (
+	"github.com/ahmadrezamusthafa/astvalidator/internal/valuetype"
+)
+
 const (
 	LogicalOperatorAnd = "AND"
 	LogicalOperatorOr  = "OR"
 
 	LogicalOperatorAndSyntax = "&&"
 	LogicalOperatorOrSyntax  = "||"
+
+	AnnotationSyntax = ":"
 )
 
 const (
@@ -14,6 +20,27 @@ const (
 	OperatorLessThanEqual    = "<="
 	OperatorGreaterThan      = ">"
 	OperatorGreaterThanEqual = ">="
+	OperatorIsMissing        = "is missing"
+	OperatorIsNotMissing     = "is not missing"
+)
+
+const (
+	AggregateCount    = "count"
+	AggregateDistinct = "distinct"
+	AggregateSum      = "sum"
+	AggregateAvg      = "avg"
+	AggregateMin      = "min"
+	AggregateMax      = "max"
+)
+
+const (
+	ClauseOrderBy = "order by"
+	ClauseLimit   = "limit"
+	ClauseOffset  = "offset"
+	ClauseSelect  = "select"
+
+	OrderAscending  = "asc"
+	OrderDescending = "desc"
 )
 
 const (
@@ -24,12 +51,28 @@ const (
 )
 
 const (
-	TypeTime         = 1
-	TypeNumeric      = 2
-	TypeAlphanumeric = 3
+	TypeTime         = valuetype.Time
+	TypeNumeric      = valuetype.Numeric
+	TypeAlphanumeric = valuetype.Alphanumeric
+)
+
+const (
+	ErrorCodeEqual            = "equal"
+	ErrorCodeLessThan         = "less_than"
+	ErrorCodeLessThanEqual    = "less_than_equal"
+	ErrorCodeGreaterThan      = "greater_than"
+	ErrorCodeGreaterThanEqual = "greater_than_equal"
+	ErrorCodeMissing          = "missing"
+)
+
+const (
+	LocaleEnglish    = "en"
+	LocaleIndonesian = "id"
+
+	DefaultLocale = LocaleEnglish
 )
 
-const DateTimeFormat = "2006-01-02 15:04:05"
+const DateTimeFormat = valuetype.DateTimeFormat
 
 const (
 	ErrorMessageInvalidData        = "data can't be %s"
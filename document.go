@@ -0,0 +1,88 @@
+package astvalidator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+func lookupPath(value reflect.Value, path string) (interface{}, bool) {
+	for len(path) > 0 {
+		segment := path
+		if index := strings.IndexByte(path, '.'); index >= 0 {
+			segment, path = path[:index], path[index+1:]
+		} else {
+			path = ""
+		}
+		var ok bool
+		value, ok = lookupSegment(value, segment)
+		if !ok {
+			return nil, false
+		}
+	}
+	value = indirectValue(value)
+	if !value.IsValid() || !value.CanInterface() {
+		return nil, true
+	}
+	return value.Interface(), true
+}
+
+func hasNullPrefix(value reflect.Value, path string) bool {
+	for i := 1; i < len(path); i++ {
+		if path[i] != '.' {
+			continue
+		}
+		if prefix, found := lookupPath(value, path[:i]); found && prefix == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func lookupSegment(value reflect.Value, segment string) (reflect.Value, bool) {
+	value = indirectValue(value)
+	if !value.IsValid() {
+		return value, false
+	}
+	switch value.Kind() {
+	case reflect.Map:
+		keyType := value.Type().Key()
+		if keyType.Kind() != reflect.String {
+			return value, false
+		}
+		item := value.MapIndex(reflect.ValueOf(segment).Convert(keyType))
+		return item, item.IsValid()
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= value.Len() {
+			return value, false
+		}
+		return value.Index(index), true
+	case reflect.Struct:
+		field, ok := getStructPlan(value.Type()).fields[segment]
+		if !ok {
+			return value, false
+		}
+		return value.FieldByIndex(field.index), true
+	default:
+		return value, false
+	}
+}
+
+func indirectValue(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+func compareValue(value interface{}, attribute *Attribute) (bool, error) {
+	if value == nil {
+		return false, errNullValue
+	}
+	rValue := reflect.ValueOf(value)
+	return selectComparator(rValue.Type())(rValue, attribute)
+}
@@ -0,0 +1,138 @@
+package astvalidator
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestCondition_ValidateDocument(t *testing.T) {
+	document := `{
+		"id": 1,
+		"name": "Budi",
+		"active": true,
+		"deleted_at": null,
+		"join_date": "2015-10-09 00:00:00",
+		"balance": 9007199254740993,
+		"profile": {"age": 30, "division": "engineering"},
+		"tags": ["new-member", "vip"],
+		"orders": [{"amount": 150000}, {"amount": 20000}]
+	}`
+
+	var floatDocument map[string]interface{}
+	if err := json.Unmarshal([]byte(document), &floatDocument); err != nil {
+		t.Fatal(err)
+	}
+	var numberDocument map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewBufferString(document))
+	decoder.UseNumber()
+	if err := decoder.Decode(&numberDocument); err != nil {
+		t.Fatal(err)
+	}
+
+	type args struct {
+		query  string
+		object interface{}
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantIsValid bool
+		wantErr     bool
+	}{
+		{
+			name: "Normal case - nested map path",
+			args: args{
+				query:  `id=1 && profile.age>18 && profile.division=engineering`,
+				object: floatDocument,
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Normal case - slice index path",
+			args: args{
+				query:  `tags.1=vip && orders.0.amount>=100000`,
+				object: floatDocument,
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Normal case - time and bool coercion",
+			args: args{
+				query:  `join_date>"2015-01-01 00:00:00" && active=true`,
+				object: floatDocument,
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Normal case - json number keeps integer precision",
+			args: args{
+				query:  `balance>9007199254740992 && id=1`,
+				object: numberDocument,
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Normal case - missing and null attributes",
+			args: args{
+				query:  `profile.level=3 || deleted_at=2020`,
+				object: floatDocument,
+			},
+			wantIsValid: false,
+		},
+		{
+			name: "Normal case - custom string keyed map",
+			args: args{
+				query: `division=finance && level>2`,
+				object: map[string]string{
+					"division": "finance",
+					"level":    "3",
+				},
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Normal case - map nested in struct",
+			args: args{
+				query: `id=7 && meta.source=web`,
+				object: struct {
+					ID   int                    `json:"id"`
+					Meta map[string]interface{} `json:"meta"`
+				}{
+					ID:   7,
+					Meta: map[string]interface{}{"source": "web"},
+				},
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Error case - invalid numeric literal",
+			args: args{
+				query:  `profile.age>abc`,
+				object: floatDocument,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error case - non string keyed map",
+			args: args{
+				query:  `id=1`,
+				object: map[int]string{1: "1"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _ := GenerateCondition(tt.args.query)
+			gotIsValid, err := condition.Validate(tt.args.object)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Condition.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotIsValid != tt.wantIsValid {
+				t.Errorf("Condition.Validate() = %v, want %v", gotIsValid, tt.wantIsValid)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package astvalidator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrNilData          = fmt.Errorf(ErrorMessageInvalidData, "nil")
+	ErrNilPointer       = fmt.Errorf(ErrorMessageInvalidData, "nil pointer")
+	ErrEmptyData        = fmt.Errorf(ErrorMessageInvalidData, "empty slice")
+	ErrUnsupportedType  = errors.New("unsupported type")
+	ErrUnknownAttribute = errors.New("unknown attribute")
+	ErrInvalidQuery     = errors.New("invalid query")
+
+	ErrInvalidBytecode    = errors.New("invalid bytecode")
+	ErrStepBudgetExceeded = errors.New("step budget exceeded")
+)
+
+type UnsupportedTypeError struct {
+	Want string
+	Got  string
+}
+
+type TypeMismatchError struct {
+	Attribute string
+	Want      string
+	Got       string
+}
+
+type UnknownAttributeError struct {
+	Attribute string
+}
+
+type ParseError struct {
+	Attribute string
+	Value     string
+	Want      string
+	Err       error
+}
+
+type QueryError struct {
+	Clause string
+	Reason string
+}
+
+func newUnsupportedTypeError(want string, rType reflect.Type) *UnsupportedTypeError {
+	got := "nil"
+	if rType != nil {
+		got = rType.String()
+	}
+	return &UnsupportedTypeError{
+		Want: want,
+		Got:  got,
+	}
+}
+
+func newParseError(attribute *Attribute, want string, err error) *ParseError {
+	return &ParseError{
+		Attribute: attribute.Name,
+		Value:     attribute.Value,
+		Want:      want,
+		Err:       err,
+	}
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf(ErrorMessageInvalidType+", got %s", e.Want, e.Got)
+}
+
+func (e *UnsupportedTypeError) Is(target error) bool {
+	return target == ErrUnsupportedType
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("attribute %s: "+ErrorMessageInvalidType+", got %s", e.Attribute, e.Want, e.Got)
+}
+
+func (e *UnknownAttributeError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrUnknownAttribute, e.Attribute)
+}
+
+func (e *UnknownAttributeError) Is(target error) bool {
+	return target == ErrUnknownAttribute
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("attribute %s: unable to parse %q as %s: %v", e.Attribute, e.Value, e.Want, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%s: %s clause: %s", ErrInvalidQuery, e.Clause, e.Reason)
+}
+
+func (e *QueryError) Is(target error) bool {
+	return target == ErrInvalidQuery
+}
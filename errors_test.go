@@ -0,0 +1,124 @@
+package astvalidator
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestErrors(t *testing.T) {
+	type Account struct {
+		ID       int       `json:"id"`
+		Score    float64   `json:"score"`
+		JoinDate time.Time `json:"join_date"`
+	}
+	var nilAccount *Account
+
+	tests := []struct {
+		name     string
+		validate func() error
+		wantIs   error
+		wantAs   interface{}
+	}{
+		{
+			name: "Validate - nil data",
+			validate: func() error {
+				condition, _ := GenerateCondition(`id=1`)
+				_, err := condition.Validate(nil)
+				return err
+			},
+			wantIs: ErrNilData,
+		},
+		{
+			name: "Validate - nil pointer",
+			validate: func() error {
+				condition, _ := GenerateCondition(`id=1`)
+				_, err := condition.Validate(nilAccount)
+				return err
+			},
+			wantIs: ErrNilPointer,
+		},
+		{
+			name: "Validate - unsupported type",
+			validate: func() error {
+				condition, _ := GenerateCondition(`id=1`)
+				_, err := condition.Validate(10)
+				return err
+			},
+			wantIs: ErrUnsupportedType,
+			wantAs: new(*UnsupportedTypeError),
+		},
+		{
+			name: "Validate - integer literal",
+			validate: func() error {
+				condition, _ := GenerateCondition(`id>abc`)
+				_, err := condition.Validate(Account{})
+				return err
+			},
+			wantIs: strconv.ErrSyntax,
+			wantAs: new(*ParseError),
+		},
+		{
+			name: "Validate - time literal",
+			validate: func() error {
+				condition, _ := GenerateCondition(`join_date>yesterday`)
+				_, err := condition.Validate(Account{})
+				return err
+			},
+			wantAs: new(*ParseError),
+		},
+		{
+			name: "ValidateObjects - empty slice",
+			validate: func() error {
+				condition, _ := GenerateCondition(`id=1`)
+				_, err := condition.ValidateObjects([]interface{}{})
+				return err
+			},
+			wantIs: ErrEmptyData,
+		},
+		{
+			name: "ValidateObjects - namespace is not a struct",
+			validate: func() error {
+				condition, _ := GenerateCondition(`map.id=1`)
+				_, err := condition.ValidateObjects([]interface{}{Account{}, map[string]interface{}{}})
+				return err
+			},
+			wantAs: new(*TypeMismatchError),
+		},
+		{
+			name: "FilterSlice - unsupported type",
+			validate: func() error {
+				condition, _ := GenerateCondition(`id=1`)
+				_, err := condition.FilterSlice(Account{})
+				return err
+			},
+			wantIs: ErrUnsupportedType,
+		},
+		{
+			name: "FilterSlice - float literal",
+			validate: func() error {
+				condition, _ := GenerateCondition(`score>high`)
+				_, err := condition.FilterSlice([]Account{{}})
+				return err
+			},
+			wantIs: strconv.ErrSyntax,
+			wantAs: new(*ParseError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate()
+			if err == nil {
+				t.Errorf("expected error")
+				return
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("errors.Is(%v, %v) = false", err, tt.wantIs)
+			}
+			if tt.wantAs != nil && !errors.As(err, tt.wantAs) {
+				t.Errorf("errors.As(%v, %T) = false", err, tt.wantAs)
+			}
+		})
+	}
+}
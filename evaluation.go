@@ -0,0 +1,257 @@
+package astvalidator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+)
+
+type EvaluationStats struct {
+	LeavesVisited        int `json:"leaves_visited"`
+	LeavesShortCircuited int `json:"leaves_short_circuited"`
+	ProviderCalls        int `json:"provider_calls"`
+}
+
+type evaluation struct {
+	ctx          context.Context
+	resolver     AttributeResolver
+	provided     map[string]providedValue
+	stats        EvaluationStats
+	tracing      bool
+	exhaustive   bool
+	trace        *Trace
+	locale       string
+	catalog      *Catalog
+	mode         Mode
+	unknownAs    UnknownMapping
+	stepBudget   int
+	workers      int
+	chunkSize    int
+	errorHandler ErrorHandler
+	explain      bool
+	having       *Condition
+	root         structResolver
+}
+
+type Option func(e *evaluation)
+
+var evaluationPool = sync.Pool{
+	New: func() interface{} {
+		return &evaluation{}
+	},
+}
+
+type Mode int
+
+const (
+	ModeLenient Mode = iota
+	ModeStrict
+)
+
+type lenientError struct {
+	err error
+}
+
+type providedValue struct {
+	value interface{}
+	err   error
+}
+
+func (c *Condition) newEvaluation(ctx context.Context, data interface{}, opts []Option) (*evaluation, error) {
+	if data == nil {
+		return nil, ErrNilData
+	}
+	resolver, err := newResolver(data)
+	if err != nil {
+		return nil, err
+	}
+	return c.newEvaluationWith(ctx, resolver, opts), nil
+}
+
+func (c *Condition) newEvaluationWith(ctx context.Context, resolver AttributeResolver, opts []Option) *evaluation {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	e := &evaluation{
+		ctx:      ctx,
+		resolver: resolver,
+		locale:   DefaultLocale,
+	}
+	for _, opt := range c.options {
+		opt(e)
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+func (c *Condition) acquireEvaluation(ctx context.Context, rValue reflect.Value, opts []Option) *evaluation {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	e := evaluationPool.Get().(*evaluation)
+	e.ctx = ctx
+	e.locale = DefaultLocale
+	e.root.value = rValue
+	e.resolver = &e.root
+	for _, opt := range c.options {
+		opt(e)
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+func (e *evaluation) release() {
+	*e = evaluation{}
+	evaluationPool.Put(e)
+}
+
+func (c *Condition) resolveOptions(opts []Option) *evaluation {
+	e := &evaluation{}
+	for _, opt := range c.options {
+		opt(e)
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+func (c *Condition) SetOptions(opts ...Option) {
+	c.options = opts
+}
+
+func WithMode(mode Mode) Option {
+	return func(e *evaluation) {
+		e.mode = mode
+	}
+}
+
+func WithLocale(locale string) Option {
+	return func(e *evaluation) {
+		e.locale = locale
+	}
+}
+
+func WithCatalog(catalog *Catalog) Option {
+	return func(e *evaluation) {
+		e.catalog = catalog
+	}
+}
+
+func (e *evaluation) compareAttribute(attribute *Attribute) (truth Truth, err error) {
+	if attribute == nil {
+		return TruthFalse, nil
+	}
+	if err := e.ctx.Err(); err != nil {
+		return TruthFalse, err
+	}
+	e.stats.LeavesVisited++
+	if e.tracing {
+		defer e.traceValue(attribute)
+	}
+	switch attribute.Operator {
+	case OperatorIsMissing, OperatorIsNotMissing:
+		isMissing, err := e.isMissing(attribute)
+		if err != nil {
+			return TruthFalse, err
+		}
+		return TruthOf(isMissing == (attribute.Operator == OperatorIsMissing)), nil
+	}
+
+	var isValid bool
+	if function, path, ok := parseAggregate(attribute.Name); ok {
+		isValid, err = e.compareAggregate(function, path, attribute)
+	} else if comparer, ok := e.resolver.(attributeComparer); ok {
+		isValid, err = comparer.compareAttribute(e, attribute)
+	} else {
+		value, found := e.resolver.Resolve(attribute.Name)
+		if !found {
+			err = newUnknownAttributeError(attribute)
+		} else {
+			isValid, err = e.compareValue(value, attribute)
+		}
+	}
+	return e.truth(isValid, err)
+}
+
+func (e *evaluation) truth(isValid bool, err error) (Truth, error) {
+	switch err := err.(type) {
+	case nil:
+		return TruthOf(isValid), nil
+	case *lenientError:
+		if e.mode == ModeStrict {
+			return TruthFalse, err.err
+		}
+		if _, ok := err.err.(*UnknownAttributeError); ok {
+			return TruthUnknown, nil
+		}
+		return TruthFalse, nil
+	default:
+		if err == errNullValue {
+			return TruthUnknown, nil
+		}
+		return TruthFalse, err
+	}
+}
+
+func (e *evaluation) isMissing(attribute *Attribute) (bool, error) {
+	if function, path, ok := parseAggregate(attribute.Name); ok {
+		value, err := e.aggregate(function, path, attribute)
+		var unknown *UnknownAttributeError
+		if errors.As(err, &unknown) {
+			return true, nil
+		}
+		return value == nil, err
+	}
+	value, found := e.resolver.Resolve(attribute.Name)
+	if !found {
+		return true, nil
+	}
+	return e.isMissingValue(attribute.Name, value)
+}
+
+func (e *evaluation) isMissingValue(name string, value interface{}) (bool, error) {
+	value, err := e.provide(name, value)
+	if err != nil {
+		return false, err
+	}
+	value, _ = lookupPath(reflect.ValueOf(value), "")
+	return value == nil, nil
+}
+
+func (e *evaluation) compareValue(value interface{}, attribute *Attribute) (bool, error) {
+	value, err := e.provide(attribute.Name, value)
+	if err != nil {
+		return false, err
+	}
+	return compareValue(value, attribute)
+}
+
+func newUnknownAttributeError(attribute *Attribute) error {
+	return &lenientError{
+		err: &UnknownAttributeError{Attribute: attribute.Name},
+	}
+}
+
+func newIncomparableError(attribute *Attribute, want, got string) error {
+	return &lenientError{
+		err: &TypeMismatchError{
+			Attribute: attribute.Name,
+			Want:      want,
+			Got:       got,
+		},
+	}
+}
+
+func (e *lenientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *lenientError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,187 @@
+package astvalidator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCondition_ValidateMode(t *testing.T) {
+	type Profile struct {
+		City string `json:"city"`
+	}
+	type Account struct {
+		ID       int       `json:"id"`
+		Name     string    `json:"name"`
+		Active   bool      `json:"active"`
+		JoinDate time.Time `json:"join_date"`
+		Manager  *Profile  `json:"manager"`
+	}
+	account := Account{
+		ID:       1,
+		Name:     "budi",
+		Active:   true,
+		JoinDate: time.Date(2015, 10, 9, 0, 0, 0, 0, time.UTC),
+	}
+	document := map[string]interface{}{
+		"id":   1,
+		"name": "budi",
+	}
+
+	type args struct {
+		query         string
+		conditionOpts []Option
+		callOpts      []Option
+		data          interface{}
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantIsValid  bool
+		wantErrIs    error
+		wantMismatch bool
+		wantParseErr bool
+	}{
+		{
+			name: "Lenient - unknown struct attribute is false",
+			args: args{
+				query: `id=1 && brand=nike`,
+				data:  account,
+			},
+			wantIsValid: false,
+		},
+		{
+			name: "Strict - unknown struct attribute",
+			args: args{
+				query:    `id=1 && brand=nike`,
+				callOpts: []Option{WithMode(ModeStrict)},
+				data:     account,
+			},
+			wantErrIs: ErrUnknownAttribute,
+		},
+		{
+			name: "Strict - unknown map attribute selected on condition",
+			args: args{
+				query:         `id=1 || brand=nike`,
+				conditionOpts: []Option{WithMode(ModeStrict)},
+				data:          document,
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Strict - unknown map attribute is reached",
+			args: args{
+				query:         `id=2 || brand=nike`,
+				conditionOpts: []Option{WithMode(ModeStrict)},
+				data:          document,
+			},
+			wantErrIs: ErrUnknownAttribute,
+		},
+		{
+			name: "Lenient - call option overrides condition mode",
+			args: args{
+				query:         `id=2 || brand=nike`,
+				conditionOpts: []Option{WithMode(ModeStrict)},
+				callOpts:      []Option{WithMode(ModeLenient)},
+				data:          document,
+			},
+			wantIsValid: false,
+		},
+		{
+			name: "Lenient - ordering on alphanumeric literal is false",
+			args: args{
+				query: `name>abc`,
+				data:  account,
+			},
+			wantIsValid: false,
+		},
+		{
+			name: "Strict - ordering on alphanumeric literal",
+			args: args{
+				query:    `name>abc`,
+				callOpts: []Option{WithMode(ModeStrict)},
+				data:     account,
+			},
+			wantMismatch: true,
+		},
+		{
+			name: "Strict - ordering on bool",
+			args: args{
+				query:    `active>1`,
+				callOpts: []Option{WithMode(ModeStrict)},
+				data:     account,
+			},
+			wantMismatch: true,
+		},
+		{
+			name: "Strict - time attribute with non time literal",
+			args: args{
+				query:    `join_date>2015`,
+				callOpts: []Option{WithMode(ModeStrict)},
+				data:     account,
+			},
+			wantParseErr: true,
+		},
+		{
+			name: "Strict - nil nested pointer is unknown",
+			args: args{
+				query:    `manager.city=jakarta`,
+				callOpts: []Option{WithMode(ModeStrict)},
+				data:     &account,
+			},
+			wantIsValid: false,
+		},
+		{
+			name: "Strict - nil nested pointer does not decide an or",
+			args: args{
+				query:    `manager.city=jakarta || id=1`,
+				callOpts: []Option{WithMode(ModeStrict), WithUnknownAs(UnknownAsError)},
+				data:     account,
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Strict - unknown field behind a nil nested pointer",
+			args: args{
+				query:    `manager.country=id`,
+				callOpts: []Option{WithMode(ModeStrict)},
+				data:     account,
+			},
+			wantErrIs: ErrUnknownAttribute,
+		},
+		{
+			name: "Strict - valid condition",
+			args: args{
+				query:    `id=1 && name=budi && join_date>"2015-01-01 00:00:00"`,
+				callOpts: []Option{WithMode(ModeStrict)},
+				data:     account,
+			},
+			wantIsValid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _ := GenerateCondition(tt.args.query, tt.args.conditionOpts...)
+			gotIsValid, err := condition.Validate(tt.args.data, tt.args.callOpts...)
+			wantErr := tt.wantErrIs != nil || tt.wantMismatch || tt.wantParseErr
+			if (err != nil) != wantErr {
+				t.Errorf("Condition.Validate() error = %v, wantErr %v", err, wantErr)
+				return
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("Condition.Validate() error = %v, want %v", err, tt.wantErrIs)
+			}
+			var mismatch *TypeMismatchError
+			if tt.wantMismatch && !errors.As(err, &mismatch) {
+				t.Errorf("Condition.Validate() error = %v, want TypeMismatchError", err)
+			}
+			var parseErr *ParseError
+			if tt.wantParseErr && !errors.As(err, &parseErr) {
+				t.Errorf("Condition.Validate() error = %v, want ParseError", err)
+			}
+			if gotIsValid != tt.wantIsValid {
+				t.Errorf("Condition.Validate() = %v, want %v", gotIsValid, tt.wantIsValid)
+			}
+		})
+	}
+}
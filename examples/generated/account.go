@@ -0,0 +1,34 @@
+package generated
+
+import (
+	"time"
+)
+
+//go:generate go run github.com/ahmadrezamusthafa/astvalidator/cmd/astvalidator-gen -type Account -rules account.rules -test
+
+type Status string
+
+type Profile struct {
+	City  string `json:"city"`
+	Level int8   `json:"level"`
+}
+
+type Audit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type Account struct {
+	Audit
+	ID       int       `json:"id"`
+	MemberID string    `json:"member_id"`
+	Division string    `json:"division"`
+	Status   Status    `json:"status"`
+	Score    float64   `json:"score"`
+	Points   uint32    `json:"points"`
+	Active   bool      `json:"active"`
+	JoinDate time.Time `json:"join_date"`
+	Email    *string   `json:"email"`
+	Age      *int      `json:"age"`
+	Profile  Profile   `json:"profile"`
+	Manager  *Profile  `json:"manager"`
+}
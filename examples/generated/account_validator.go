@@ -0,0 +1,86 @@
+// Code generated by astvalidator-gen. DO NOT EDIT.
+
+package generated
+
+import (
+	"strconv"
+	"time"
+)
+
+// MatchAccount reports whether a satisfies the condition:
+//
+//	(division=engineering || division=finance) && status=active && score>=7.5 && (points>100 || member_id>=1000 || (active=true && join_date<"2016-01-01 00:00:00")) && (email is not missing || age>=18) && profile.level<=3 && (manager.city=jakarta || manager is missing) && created_by=system
+func MatchAccount(a *Account) bool {
+	if a == nil {
+		return false
+	}
+	// unknown lies between false and true, so && is min and || is max
+	const (
+		isFalse = iota
+		isUnknown
+		isTrue
+	)
+	t0 := isFalse
+	if a.Division == "engineering" {
+		t0 = isTrue
+	}
+	t1 := isFalse
+	if a.Division == "finance" {
+		t1 = isTrue
+	}
+	t2 := isFalse
+	if a.Status == "active" {
+		t2 = isTrue
+	}
+	t3 := isFalse
+	if float64(a.Score) >= 7.5 {
+		t3 = isTrue
+	}
+	t4 := isFalse
+	if float64(a.Points) > 100 {
+		t4 = isTrue
+	}
+	t5 := isFalse
+	if value, err := strconv.ParseFloat(string(a.MemberID), 64); err == nil && value >= 1000.0 {
+		t5 = isTrue
+	}
+	t6 := isFalse
+	if a.Active {
+		t6 = isTrue
+	}
+	t7 := isFalse
+	if a.JoinDate.Before(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t7 = isTrue
+	}
+	t8 := isFalse
+	if a.Email != nil {
+		t8 = isTrue
+	}
+	t9 := isUnknown
+	if a.Age != nil {
+		t9 = isFalse
+		if int64(*a.Age) >= 18 {
+			t9 = isTrue
+		}
+	}
+	t10 := isFalse
+	if int64(a.Profile.Level) <= 3 {
+		t10 = isTrue
+	}
+	t11 := isUnknown
+	if a.Manager != nil {
+		t11 = isFalse
+		if a.Manager.City == "jakarta" {
+			t11 = isTrue
+		}
+	}
+	t12 := isFalse
+	if a.Manager == nil {
+		t12 = isTrue
+	}
+	t13 := isFalse
+	if a.CreatedBy == "system" {
+		t13 = isTrue
+	}
+	return min(max(t0, t1), t2, t3, max(t4, t5, min(t6, t7)), max(t8, t9), t10, max(t11, t12), t13) == isTrue
+}
@@ -0,0 +1,54 @@
+// Code generated by astvalidator-gen. DO NOT EDIT.
+
+package generated
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ahmadrezamusthafa/astvalidator"
+)
+
+func TestMatchAccount(t *testing.T) {
+	condition, err := astvalidator.GenerateCondition("(division=engineering || division=finance) && status=active && score>=7.5 && (points>100 || member_id>=1000 || (active=true && join_date<\"2016-01-01 00:00:00\")) && (email is not missing || age>=18) && profile.level<=3 && (manager.city=jakarta || manager is missing) && created_by=system")
+	if err != nil {
+		t.Fatalf("GenerateCondition() error = %v", err)
+	}
+	if MatchAccount(nil) {
+		t.Errorf("MatchAccount(nil) = true, want false")
+	}
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 1000; n++ {
+		a := &Account{}
+		a.Division = []string{"engineering", "engineeringx", "", "finance", "financex"}[r.Intn(5)]
+		a.Status = []Status{"active", "activex", ""}[r.Intn(3)]
+		a.Score = []float64{6.5, 7.5, 8.5}[r.Intn(3)]
+		a.Points = []uint32{99, 100, 101}[r.Intn(3)]
+		a.MemberID = []string{"1000", "1000x", "", "999", "1001"}[r.Intn(5)]
+		a.Active = []bool{true, false}[r.Intn(2)]
+		a.JoinDate = []time.Time{time.Date(2015, 12, 31, 23, 59, 59, 0, time.UTC), time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2016, 1, 1, 0, 0, 1, 0, time.UTC)}[r.Intn(3)]
+		if i := r.Intn(2); i < 1 {
+			value := []string{*new(string)}[i]
+			a.Email = &value
+		}
+		if i := r.Intn(4); i < 3 {
+			value := []int{17, 18, 19}[i]
+			a.Age = &value
+		}
+		a.Profile.Level = []int8{2, 3, 4}[r.Intn(3)]
+		a.Manager = &Profile{}
+		a.Manager.City = []string{"jakarta", "jakartax", ""}[r.Intn(3)]
+		a.CreatedBy = []string{"system", "systemx", ""}[r.Intn(3)]
+		if r.Intn(5) == 0 {
+			a.Manager = nil
+		}
+		want, err := condition.Validate(a)
+		if err != nil {
+			t.Fatalf("Condition.Validate() error = %v", err)
+		}
+		if got := MatchAccount(a); got != want {
+			t.Errorf("MatchAccount(%+v) = %v, Condition.Validate() = %v", *a, got, want)
+		}
+	}
+}
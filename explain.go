@@ -0,0 +1,128 @@
+package astvalidator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+type Trace struct {
+	Operator       string      `json:"operator,omitempty"`
+	Attribute      *Attribute  `json:"attribute,omitempty"`
+	Value          interface{} `json:"value,omitempty"`
+	Result         bool        `json:"result"`
+	Unknown        bool        `json:"unknown,omitempty"`
+	Missing        bool        `json:"missing,omitempty"`
+	ShortCircuited bool        `json:"short_circuited,omitempty"`
+	Error          string      `json:"error,omitempty"`
+	Reason         string      `json:"reason"`
+	Conditions     []*Trace    `json:"conditions,omitempty"`
+}
+
+func (c *Condition) ValidateWithTrace(data interface{}, opts ...Option) (isValid bool, trace *Trace, err error) {
+	e, err := c.newEvaluation(context.Background(), data, opts)
+	if err != nil {
+		return false, nil, err
+	}
+	e.tracing = true
+	truth, err := c.validateAttribute(e)
+	if err != nil {
+		return false, e.trace, err
+	}
+	isValid, err = e.result(truth)
+	return isValid, e.trace, err
+}
+
+func (e *evaluation) traceCondition(c *Condition) func(truth *Truth, err *error) {
+	parent := e.trace
+	node := &Trace{
+		Operator:  c.Operator,
+		Attribute: c.Attribute,
+	}
+	if len(c.Conditions) > 0 {
+		node.Attribute = nil
+	}
+	if parent != nil {
+		parent.Conditions = append(parent.Conditions, node)
+	}
+	e.trace = node
+	return func(truth *Truth, err *error) {
+		node.Result = *truth == TruthTrue
+		node.Unknown = *truth == TruthUnknown
+		if *err != nil {
+			node.Error = (*err).Error()
+		}
+		node.Reason = node.reason()
+		if parent != nil {
+			e.trace = parent
+		}
+	}
+}
+
+func (e *evaluation) traceShortCircuit(c *Condition) {
+	node := &Trace{
+		Operator:       c.Operator,
+		Attribute:      c.Attribute,
+		ShortCircuited: true,
+	}
+	if len(c.Conditions) > 0 {
+		node.Attribute = nil
+	}
+	node.Reason = node.reason()
+	e.trace.Conditions = append(e.trace.Conditions, node)
+}
+
+func (e *evaluation) traceValue(attribute *Attribute) {
+	if function, path, ok := parseAggregate(attribute.Name); ok {
+		value, err := e.aggregate(function, path, attribute)
+		e.trace.Missing = err != nil
+		e.trace.Value = value
+		return
+	}
+	value, found := e.resolver.Resolve(attribute.Name)
+	if !found {
+		e.trace.Missing = true
+		return
+	}
+	value, err := e.provide(attribute.Name, value)
+	if err != nil {
+		return
+	}
+	if value, ok := lookupPath(reflect.ValueOf(value), ""); ok {
+		e.trace.Value = value
+	}
+}
+
+func (t *Trace) reason() string {
+	switch {
+	case t.Error != "":
+		return "error: " + t.Error
+	case t.ShortCircuited:
+		return "not evaluated, result already decided by previous conditions"
+	case t.Attribute == nil:
+		passed := 0
+		for _, subTrace := range t.Conditions {
+			if subTrace.Result && !subTrace.ShortCircuited {
+				passed++
+			}
+		}
+		return fmt.Sprintf("%d of %d conditions passed, group is %s", passed, len(t.Conditions), t.truth())
+	case t.Missing && t.Unknown:
+		return fmt.Sprintf("%s is missing, result is unknown", t.Attribute.Name)
+	case t.Unknown:
+		return fmt.Sprintf("%s is null, result is unknown", t.Attribute.Name)
+	case t.Result:
+		return fmt.Sprintf("%s is %v, satisfies %s %s", t.Attribute.Name, t.Value, t.Attribute.Operator, t.Attribute.Value)
+	default:
+		return fmt.Sprintf("%s is %v, does not satisfy %s %s", t.Attribute.Name, t.Value, t.Attribute.Operator, t.Attribute.Value)
+	}
+}
+
+func (t *Trace) truth() Truth {
+	switch {
+	case t.Unknown:
+		return TruthUnknown
+	default:
+		return TruthOf(t.Result)
+	}
+}
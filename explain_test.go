@@ -0,0 +1,64 @@
+package astvalidator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCondition_ValidateWithTrace(t *testing.T) {
+	score := 75
+	object := struct {
+		ID       int    `json:"id"`
+		Division string `json:"division"`
+		Score    *int   `json:"score"`
+	}{
+		ID:       1,
+		Division: "finance",
+		Score:    &score,
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		wantIsValid bool
+		wantTrace   string
+		wantErr     bool
+	}{
+		{
+			name:        "Normal case - failed leaf",
+			query:       `id=1 && score>=80`,
+			wantIsValid: false,
+			wantTrace:   `{"result":false,"reason":"1 of 2 conditions passed, group is false","conditions":[{"attribute":{"name":"id","operator":"=","value":"1"},"value":1,"result":true,"reason":"id is 1, satisfies = 1"},{"operator":"AND","attribute":{"name":"score","operator":"\u003e=","value":"80"},"value":75,"result":false,"reason":"score is 75, does not satisfy \u003e= 80"}]}`,
+		},
+		{
+			name:        "Normal case - missing and short circuited",
+			query:       `brand=nike || (division=people && id=1)`,
+			wantIsValid: false,
+			wantTrace:   `{"result":false,"unknown":true,"reason":"0 of 2 conditions passed, group is unknown","conditions":[{"attribute":{"name":"brand","operator":"=","value":"nike"},"result":false,"unknown":true,"missing":true,"reason":"brand is missing, result is unknown"},{"operator":"OR","result":false,"reason":"0 of 2 conditions passed, group is false","conditions":[{"attribute":{"name":"division","operator":"=","value":"people"},"value":"finance","result":false,"reason":"division is finance, does not satisfy = people"},{"operator":"AND","attribute":{"name":"id","operator":"=","value":"1"},"result":false,"short_circuited":true,"reason":"not evaluated, result already decided by previous conditions"}]}]}`,
+		},
+		{
+			name:        "Error case - invalid literal",
+			query:       `score>abc`,
+			wantIsValid: false,
+			wantTrace:   `{"result":false,"error":"attribute score: unable to parse \"abc\" as integer: strconv.ParseInt: parsing \"abc\": invalid syntax","reason":"error: attribute score: unable to parse \"abc\" as integer: strconv.ParseInt: parsing \"abc\": invalid syntax","conditions":[{"attribute":{"name":"score","operator":"\u003e","value":"abc"},"value":75,"result":false,"error":"attribute score: unable to parse \"abc\" as integer: strconv.ParseInt: parsing \"abc\": invalid syntax","reason":"error: attribute score: unable to parse \"abc\" as integer: strconv.ParseInt: parsing \"abc\": invalid syntax"}]}`,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _ := GenerateCondition(tt.query)
+			gotIsValid, gotTrace, err := condition.ValidateWithTrace(object)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Condition.ValidateWithTrace() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotIsValid != tt.wantIsValid {
+				t.Errorf("Condition.ValidateWithTrace() = %v, want %v", gotIsValid, tt.wantIsValid)
+			}
+			traceJSON, _ := json.Marshal(gotTrace)
+			if string(traceJSON) != tt.wantTrace {
+				t.Errorf("Condition.ValidateWithTrace() trace = %s, want %s", traceJSON, tt.wantTrace)
+			}
+		})
+	}
+}
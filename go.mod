@@ -0,0 +1,3 @@
+module github.com/ahmadrezamusthafa/astvalidator
+
+go 1.23
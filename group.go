@@ -0,0 +1,134 @@
+package astvalidator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type Group struct {
+	Key        interface{}            `json:"key"`
+	Count      int                    `json:"count"`
+	Aggregates map[string]interface{} `json:"aggregates,omitempty"`
+	Records    interface{}            `json:"-"`
+}
+
+type groupKey struct {
+	rType reflect.Type
+	value string
+}
+
+type groupResolver struct {
+	by      string
+	key     interface{}
+	records reflect.Value
+}
+
+func WithHaving(having Condition) Option {
+	return func(e *evaluation) {
+		e.having = &having
+	}
+}
+
+func (c *Condition) GroupBy(data interface{}, by string, aggregates []string, opts ...Option) (groups []Group, err error) {
+	for _, aggregate := range aggregates {
+		if _, _, ok := parseAggregate(aggregate); !ok {
+			return nil, &QueryError{Clause: "group by", Reason: fmt.Sprintf("invalid aggregate %q", aggregate)}
+		}
+	}
+	filtered, err := c.FilterSlice(data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	rValue := reflect.ValueOf(filtered)
+	var (
+		indexes = make(map[groupKey]int)
+		members [][]int
+	)
+	for i := 0; i < rValue.Len(); i++ {
+		value, _ := lookupPath(rValue.Index(i), by)
+		key := groupKey{rType: reflect.TypeOf(value), value: fmt.Sprint(value)}
+		index, ok := indexes[key]
+		if !ok {
+			index = len(groups)
+			indexes[key] = index
+			groups = append(groups, Group{Key: value})
+			members = append(members, nil)
+		}
+		members[index] = append(members[index], i)
+	}
+
+	having := c.resolveOptions(opts).having
+	result := groups[:0]
+	for i, group := range groups {
+		records := reflect.MakeSlice(rValue.Type(), len(members[i]), len(members[i]))
+		for j, index := range members[i] {
+			records.Index(j).Set(rValue.Index(index))
+		}
+		resolver := &groupResolver{by: by, key: group.Key, records: records}
+		group.Count = len(members[i])
+		group.Records = records.Interface()
+		group.Aggregates, err = c.groupAggregates(resolver, aggregates, opts)
+		if err != nil {
+			return nil, err
+		}
+		if having != nil {
+			isValid, err := having.Validate(resolver, opts...)
+			if err != nil {
+				return nil, err
+			}
+			if !isValid {
+				continue
+			}
+		}
+		result = append(result, group)
+	}
+	return result, nil
+}
+
+func (c *Condition) groupAggregates(resolver *groupResolver, aggregates []string, opts []Option) (map[string]interface{}, error) {
+	if len(aggregates) == 0 {
+		return nil, nil
+	}
+	e := c.newEvaluationWith(context.Background(), resolver, opts)
+	values := make(map[string]interface{}, len(aggregates))
+	for _, aggregate := range aggregates {
+		function, path, _ := parseAggregate(aggregate)
+		value, err := e.aggregate(function, path, &Attribute{Name: aggregate})
+		if lenient, ok := err.(*lenientError); ok && e.mode != ModeStrict {
+			value, err = nil, nil
+		} else if ok {
+			err = lenient.err
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[aggregate] = value
+	}
+	return values, nil
+}
+
+func (r *groupResolver) Resolve(path string) (interface{}, bool) {
+	if path == r.by {
+		return r.key, true
+	}
+	return r.resolveAggregate(path)
+}
+
+func (r *groupResolver) resolveAggregate(path string) (interface{}, bool) {
+	segment, _, _ := strings.Cut(path, ".")
+	var (
+		values []interface{}
+		found  bool
+	)
+	for i := 0; i < r.records.Len(); i++ {
+		record := r.records.Index(i)
+		if _, ok := lookupSegment(record, segment); !ok {
+			continue
+		}
+		found = true
+		values = collectValues(record, path, values)
+	}
+	return values, found
+}
@@ -0,0 +1,227 @@
+package astvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCondition_GroupBy(t *testing.T) {
+	type Sale struct {
+		ID       int     `json:"id"`
+		Division string  `json:"division"`
+		Region   *string `json:"region"`
+		Amount   float64 `json:"amount"`
+		Status   string  `json:"status"`
+	}
+	north := "north"
+	sales := []Sale{
+		{ID: 1, Division: "engineering", Region: &north, Amount: 100, Status: "paid"},
+		{ID: 2, Division: "finance", Amount: 300, Status: "paid"},
+		{ID: 3, Division: "engineering", Amount: 50, Status: "paid"},
+		{ID: 4, Division: "engineering", Amount: 900, Status: "refunded"},
+		{ID: 5, Division: "people", Region: &north, Amount: 20, Status: "paid"},
+		{ID: 6, Division: "finance", Amount: 700, Status: "paid"},
+	}
+	documents := []map[string]interface{}{
+		{"team": "a", "score": "10"},
+		{"team": "b", "score": 4},
+		{"team": "a", "score": 30},
+		{"score": 7},
+	}
+	codes := []map[string]interface{}{
+		{"code": "007"},
+		{"code": "7"},
+		{"code": "7.0"},
+		{"code": "007"},
+		{"code": 7},
+	}
+	points := []map[string]interface{}{
+		{"team": "a", "points": 5},
+		{"team": "b", "points": 5},
+		{"team": "a", "points": 5},
+	}
+	mustCondition := func(query string) Condition {
+		condition, err := GenerateCondition(query)
+		if err != nil {
+			t.Fatalf("GenerateCondition() error = %v", err)
+		}
+		return condition
+	}
+	type group struct {
+		Key        interface{}
+		Count      int
+		Aggregates map[string]interface{}
+	}
+	tests := []struct {
+		name         string
+		query        string
+		data         interface{}
+		by           string
+		aggregates   []string
+		opts         []Option
+		want         []group
+		wantIDs      [][]int
+		wantErrIs    error
+		wantMismatch bool
+	}{
+		{
+			name:       "Normal case - groups keep first appearance order",
+			query:      `status=paid`,
+			data:       sales,
+			by:         "division",
+			aggregates: []string{"count(id)", "sum(amount)", "avg(amount)", "min(amount)", "max(amount)"},
+			want: []group{
+				{Key: "engineering", Count: 2, Aggregates: map[string]interface{}{"count(id)": 2.0, "sum(amount)": 150.0, "avg(amount)": 75.0, "min(amount)": 50.0, "max(amount)": 100.0}},
+				{Key: "finance", Count: 2, Aggregates: map[string]interface{}{"count(id)": 2.0, "sum(amount)": 1000.0, "avg(amount)": 500.0, "min(amount)": 300.0, "max(amount)": 700.0}},
+				{Key: "people", Count: 1, Aggregates: map[string]interface{}{"count(id)": 1.0, "sum(amount)": 20.0, "avg(amount)": 20.0, "min(amount)": 20.0, "max(amount)": 20.0}},
+			},
+			wantIDs: [][]int{{1, 3}, {2, 6}, {5}},
+		},
+		{
+			name:       "Normal case - having on aggregates",
+			query:      `status=paid`,
+			data:       &sales,
+			by:         "division",
+			aggregates: []string{"sum(amount)"},
+			opts:       []Option{WithHaving(mustCondition(`sum(amount) >= 100 && count(id) > 1`))},
+			want: []group{
+				{Key: "engineering", Count: 2, Aggregates: map[string]interface{}{"sum(amount)": 150.0}},
+				{Key: "finance", Count: 2, Aggregates: map[string]interface{}{"sum(amount)": 1000.0}},
+			},
+			wantIDs: [][]int{{1, 3}, {2, 6}},
+		},
+		{
+			name:    "Normal case - having on the group key",
+			query:   `amount>0`,
+			data:    sales,
+			by:      "division",
+			opts:    []Option{WithHaving(mustCondition(`division=finance || max(amount) > 800`))},
+			want:    []group{{Key: "engineering", Count: 3}, {Key: "finance", Count: 2}},
+			wantIDs: [][]int{{1, 3, 4}, {2, 6}},
+		},
+		{
+			name:    "Normal case - null keys are grouped together",
+			query:   `amount>0`,
+			data:    sales,
+			by:      "region",
+			want:    []group{{Key: "north", Count: 2}, {Key: nil, Count: 4}},
+			wantIDs: [][]int{{1, 5}, {2, 3, 4, 6}},
+		},
+		{
+			name:       "Normal case - count over the group by field",
+			query:      `amount>0`,
+			data:       sales,
+			by:         "region",
+			aggregates: []string{"count(region)", "count(id)"},
+			want: []group{
+				{Key: "north", Count: 2, Aggregates: map[string]interface{}{"count(region)": 2.0, "count(id)": 2.0}},
+				{Key: nil, Count: 4, Aggregates: map[string]interface{}{"count(region)": 0.0, "count(id)": 4.0}},
+			},
+			wantIDs: [][]int{{1, 5}, {2, 3, 4, 6}},
+		},
+		{
+			name:       "Normal case - sum over the group by field",
+			query:      `points>0`,
+			data:       points,
+			by:         "points",
+			aggregates: []string{"sum(points)", "count(points)"},
+			opts:       []Option{WithHaving(mustCondition(`points=5 && sum(points) = 15 && count(points) = 3`))},
+			want:       []group{{Key: 5, Count: 3, Aggregates: map[string]interface{}{"sum(points)": int64(15), "count(points)": 3.0}}},
+		},
+		{
+			name:  "Normal case - keys are grouped by their exact value",
+			query: `code is not missing`,
+			data:  codes,
+			by:    "code",
+			want:  []group{{Key: "007", Count: 2}, {Key: "7", Count: 1}, {Key: "7.0", Count: 1}, {Key: 7, Count: 1}},
+		},
+		{
+			name:       "Normal case - map records with numeric strings",
+			query:      `score>0`,
+			data:       documents,
+			by:         "team",
+			aggregates: []string{"sum(score)", "max(score)"},
+			want: []group{
+				{Key: "a", Count: 2, Aggregates: map[string]interface{}{"sum(score)": int64(40), "max(score)": 30.0}},
+				{Key: "b", Count: 1, Aggregates: map[string]interface{}{"sum(score)": int64(4), "max(score)": 4.0}},
+				{Key: nil, Count: 1, Aggregates: map[string]interface{}{"sum(score)": int64(7), "max(score)": 7.0}},
+			},
+		},
+		{
+			name:       "Normal case - incomparable aggregate is nil in lenient mode",
+			query:      `status=paid`,
+			data:       sales,
+			by:         "status",
+			aggregates: []string{"sum(division)"},
+			want:       []group{{Key: "paid", Count: 5, Aggregates: map[string]interface{}{"sum(division)": nil}}},
+		},
+		{
+			name:         "Negative case - incomparable aggregate in strict mode",
+			query:        `status=paid`,
+			data:         sales,
+			by:           "status",
+			aggregates:   []string{"sum(division)"},
+			opts:         []Option{WithMode(ModeStrict)},
+			wantMismatch: true,
+		},
+		{
+			name:       "Negative case - invalid aggregate",
+			query:      `status=paid`,
+			data:       sales,
+			by:         "division",
+			aggregates: []string{"median(amount)"},
+			wantErrIs:  ErrInvalidQuery,
+		},
+		{
+			name:      "Negative case - not a slice",
+			query:     `status=paid`,
+			data:      sales[0],
+			by:        "division",
+			wantErrIs: ErrUnsupportedType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := mustCondition(tt.query)
+			groups, err := condition.GroupBy(tt.data, tt.by, tt.aggregates, tt.opts...)
+			if tt.wantMismatch {
+				var mismatch *TypeMismatchError
+				if !errors.As(err, &mismatch) {
+					t.Errorf("GroupBy() error = %v, want *TypeMismatchError", err)
+				}
+				return
+			}
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("GroupBy() error = %v, want %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GroupBy() error = %v", err)
+			}
+			var got []group
+			for _, g := range groups {
+				got = append(got, group{Key: g.Key, Count: g.Count, Aggregates: g.Aggregates})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GroupBy() = %+v, want %+v", got, tt.want)
+			}
+			if tt.wantIDs == nil {
+				return
+			}
+			var gotIDs [][]int
+			for _, g := range groups {
+				var ids []int
+				for _, sale := range g.Records.([]Sale) {
+					ids = append(ids, sale.ID)
+				}
+				gotIDs = append(gotIDs, ids)
+			}
+			if !reflect.DeepEqual(gotIDs, tt.wantIDs) {
+				t.Errorf("GroupBy() records = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package valuetype
+
+import (
+	"time"
+)
+
+const (
+	Time         = 1
+	Numeric      = 2
+	Alphanumeric = 3
+)
+
+const DateTimeFormat = "2006-01-02 15:04:05"
+
+func Of(value string) int {
+	var varType, indexVal, dotCount int = Alphanumeric, 0, 0
+	for _, char := range value {
+		if char == ',' {
+			continue
+		}
+		if '0' <= char && char <= '9' {
+			if indexVal == 0 || (indexVal > 0 && dotCount == 1) {
+				varType = Numeric
+			}
+		} else if char == '.' {
+			if indexVal > 0 && varType == Numeric {
+				dotCount++
+				varType = Alphanumeric
+			}
+			if dotCount > 1 {
+				varType = Alphanumeric
+				break
+			}
+		} else {
+			varType = Alphanumeric
+			break
+		}
+		indexVal++
+	}
+	if varType == Alphanumeric {
+		if _, err := time.Parse(DateTimeFormat, value); err == nil {
+			varType = Time
+		}
+	}
+	return varType
+}
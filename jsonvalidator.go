@@ -0,0 +1,167 @@
+package astvalidator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type jsonScanner struct {
+	decoder  *json.Decoder
+	paths    map[string]bool
+	prefixes map[string]bool
+}
+
+func (c *Condition) ValidateJSON(data []byte, opts ...Option) (isValid bool, err error) {
+	if data == nil {
+		return false, ErrNilData
+	}
+	return c.ValidateJSONReader(bytes.NewReader(data), opts...)
+}
+
+func (c *Condition) ValidateJSONReader(reader io.Reader, opts ...Option) (isValid bool, err error) {
+	if reader == nil {
+		return false, ErrNilData
+	}
+	document, err := c.scanJSON(reader)
+	if err != nil {
+		return false, err
+	}
+	return c.Validate(document, opts...)
+}
+
+func (c *Condition) scanJSON(reader io.Reader) (map[string]interface{}, error) {
+	scanner := newJSONScanner(reader, c)
+	token, err := scanner.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('{') {
+		return nil, &UnsupportedTypeError{
+			Want: "json object",
+			Got:  fmt.Sprint(token),
+		}
+	}
+	return scanner.scanObject("")
+}
+
+func newJSONScanner(reader io.Reader, condition *Condition) *jsonScanner {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	scanner := &jsonScanner{
+		decoder:  decoder,
+		paths:    make(map[string]bool),
+		prefixes: make(map[string]bool),
+	}
+	condition.readAllAttributes(scanner.paths)
+	for path := range scanner.paths {
+		if _, root, ok := parseAggregate(path); ok {
+			delete(scanner.paths, path)
+			root, _, _ = strings.Cut(root, ".")
+			scanner.paths[root] = true
+		}
+	}
+	for path := range scanner.paths {
+		for i := 0; i < len(path); i++ {
+			if path[i] == '.' {
+				scanner.prefixes[path[:i]] = true
+			}
+		}
+	}
+	return scanner
+}
+
+func (s *jsonScanner) scanValue(path string) (value interface{}, found bool, err error) {
+	if s.paths[path] {
+		err = s.decoder.Decode(&value)
+		return value, err == nil, err
+	}
+	if !s.prefixes[path] {
+		return nil, false, s.skipValue()
+	}
+	token, err := s.decoder.Token()
+	if err != nil {
+		return nil, false, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object, err := s.scanObject(path)
+		return object, err == nil && len(object) > 0, err
+	case json.Delim('['):
+		array, err := s.scanArray(path)
+		return array, err == nil && len(array) > 0, err
+	default:
+		return nil, false, nil
+	}
+}
+
+func (s *jsonScanner) scanObject(path string) (map[string]interface{}, error) {
+	object := make(map[string]interface{})
+	for s.decoder.More() {
+		token, err := s.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, &UnsupportedTypeError{
+				Want: "json object key",
+				Got:  fmt.Sprint(token),
+			}
+		}
+		value, found, err := s.scanValue(joinPath(path, key))
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			object[key] = value
+		}
+	}
+	_, err := s.decoder.Token()
+	return object, err
+}
+
+func (s *jsonScanner) scanArray(path string) (map[string]interface{}, error) {
+	array := make(map[string]interface{})
+	for index := 0; s.decoder.More(); index++ {
+		key := strconv.Itoa(index)
+		value, found, err := s.scanValue(joinPath(path, key))
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			array[key] = value
+		}
+	}
+	_, err := s.decoder.Token()
+	return array, err
+}
+
+func (s *jsonScanner) skipValue() error {
+	depth := 0
+	for {
+		token, err := s.decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
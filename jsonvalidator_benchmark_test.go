@@ -0,0 +1,26 @@
+package astvalidator
+
+import "testing"
+
+//BENCHMARK ValidateJSON
+//Improvement history:
+//------------------------------------
+//	attempt	   |  time per loop
+//------------------------------------
+//  137071	      8998 ns/op (now)
+//------------------------------------
+func BenchmarkValidateJSON(b *testing.B) {
+	payload := []byte(`{
+		"id": 1,
+		"member_id": 2,
+		"division": "finance",
+		"profile": {"name": "Budi", "address": {"city": "Jakarta", "zip": "12345"}},
+		"history": [{"at": "2020-01-01 00:00:00"}, {"at": "2020-02-01 00:00:00"}]
+	}`)
+
+	query := "(id=1 && (member_id=12||member_id=2))  &&   (division=engineering || division=finance)"
+	condition, _ := GenerateCondition(query)
+	for n := 0; n < b.N; n++ {
+		condition.ValidateJSON(payload)
+	}
+}
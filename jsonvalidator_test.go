@@ -0,0 +1,99 @@
+package astvalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCondition_ValidateJSON(t *testing.T) {
+	payload := `{
+		"event": "payment.succeeded",
+		"amount": 12345678901234567,
+		"created_at": "2020-03-09 10:00:00",
+		"metadata": {"channel": "web", "ignored": {"deep": [1, 2, {"x": "y"}]}},
+		"items": [{"sku": "A-1", "qty": 2}, {"sku": "B-2", "qty": 5}],
+		"notes": null
+	}`
+
+	tests := []struct {
+		name        string
+		query       string
+		data        string
+		wantIsValid bool
+		wantErr     bool
+	}{
+		{
+			name:        "Normal case - referenced paths only",
+			query:       `event=payment.succeeded && metadata.channel=web`,
+			data:        payload,
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - json number precision",
+			query:       `amount>12345678901234566 && amount<12345678901234568`,
+			data:        payload,
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - array index and time",
+			query:       `items.1.qty>=5 && items.0.sku=A-1 && created_at>"2020-01-01 00:00:00"`,
+			data:        payload,
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - missing path",
+			query:       `metadata.region=id || items.5.qty>1`,
+			data:        payload,
+			wantIsValid: false,
+		},
+		{
+			name:    "Error case - malformed json",
+			query:   `event=payment.succeeded`,
+			data:    `{"event": "payment.succeeded"`,
+			wantErr: true,
+		},
+		{
+			name:    "Error case - not an object",
+			query:   `event=payment.succeeded`,
+			data:    `["payment.succeeded"]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _ := GenerateCondition(tt.query)
+			gotIsValid, err := condition.ValidateJSON([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Condition.ValidateJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotIsValid != tt.wantIsValid {
+				t.Errorf("Condition.ValidateJSON() = %v, want %v", gotIsValid, tt.wantIsValid)
+			}
+
+			gotIsValid, err = condition.ValidateJSONReader(strings.NewReader(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Condition.ValidateJSONReader() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotIsValid != tt.wantIsValid {
+				t.Errorf("Condition.ValidateJSONReader() = %v, want %v", gotIsValid, tt.wantIsValid)
+			}
+		})
+	}
+}
+
+func TestCondition_scanJSON(t *testing.T) {
+	condition, _ := GenerateCondition(`metadata.channel=web`)
+	document, err := condition.scanJSON(strings.NewReader(`{"event": "x", "metadata": {"channel": "web", "other": [1, 2]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := document["event"]; ok {
+		t.Errorf("Condition.scanJSON() decoded unreferenced path event")
+	}
+	metadata, ok := document["metadata"].(map[string]interface{})
+	if !ok || len(metadata) != 1 || metadata["channel"] != "web" {
+		t.Errorf("Condition.scanJSON() metadata = %v, want only channel", document["metadata"])
+	}
+}
@@ -0,0 +1,144 @@
+package astvalidator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+const defaultChunkSize = 1024
+
+type ElementError struct {
+	Index int
+	Err   error
+}
+
+func WithWorkers(workers int) Option {
+	return func(e *evaluation) {
+		e.workers = workers
+	}
+}
+
+func WithChunkSize(size int) Option {
+	return func(e *evaluation) {
+		e.chunkSize = size
+	}
+}
+
+func (c *Condition) FilterSliceParallel(ctx context.Context, data interface{}, opts ...Option) (result interface{}, err error) {
+	if data == nil {
+		return result, ErrNilData
+	}
+	data, err = indirect(data)
+	if err != nil {
+		return result, err
+	}
+	rType := reflect.TypeOf(data)
+	if rType.Kind() != reflect.Slice {
+		return result, newUnsupportedTypeError("slice", rType)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	rValue := reflect.ValueOf(data)
+	length := rValue.Len()
+	workers, chunkSize := c.parallelism(opts)
+	chunks := (length + chunkSize - 1) / chunkSize
+	if workers > chunks {
+		workers = chunks
+	}
+
+	var (
+		matches   = make([]bool, length)
+		nextChunk int64
+		wait      sync.WaitGroup
+		mutex     sync.Mutex
+		errIndex  = int64(length)
+		firstErr  error
+	)
+	setError := func(index int, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if int64(index) < atomic.LoadInt64(&errIndex) {
+			atomic.StoreInt64(&errIndex, int64(index))
+			firstErr = err
+		}
+	}
+	wait.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wait.Done()
+			match, release := c.elementMatcher(ctx, rValue, opts)
+			defer release()
+			for {
+				chunk := int(atomic.AddInt64(&nextChunk, 1) - 1)
+				start := chunk * chunkSize
+				if chunk >= chunks || int64(start) >= atomic.LoadInt64(&errIndex) {
+					return
+				}
+				if err := ctx.Err(); err != nil {
+					setError(start, err)
+					return
+				}
+				end := start + chunkSize
+				if end > length {
+					end = length
+				}
+				for i := start; i < end; i++ {
+					isValid, err := match(i)
+					if err != nil {
+						setError(i, err)
+						break
+					}
+					matches[i] = isValid
+				}
+			}
+		}()
+	}
+	wait.Wait()
+
+	if firstErr != nil {
+		if firstErr == ctx.Err() {
+			return nil, firstErr
+		}
+		return nil, &ElementError{Index: int(errIndex), Err: firstErr}
+	}
+	count := 0
+	for _, isValid := range matches {
+		if isValid {
+			count++
+		}
+	}
+	rSlice := reflect.MakeSlice(rType, count, count)
+	count = 0
+	for i, isValid := range matches {
+		if isValid {
+			rSlice.Index(count).Set(rValue.Index(i))
+			count++
+		}
+	}
+	return rSlice.Interface(), nil
+}
+
+func (c *Condition) parallelism(opts []Option) (workers, chunkSize int) {
+	e := c.resolveOptions(opts)
+	workers, chunkSize = e.workers, e.chunkSize
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+	return workers, chunkSize
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("element %d: %s", e.Index, e.Err.Error())
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
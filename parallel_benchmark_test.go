@@ -0,0 +1,41 @@
+package astvalidator
+
+import (
+	"context"
+	"testing"
+)
+
+//BENCHMARK FilterSliceParallel (100000 rows)
+//Improvement history:
+//------------------------------------
+//	attempt	   |  time per loop
+//------------------------------------
+//  163	      7462620 ns/op (now)
+//------------------------------------
+func BenchmarkFilterSliceParallel(b *testing.B) {
+	type Account struct {
+		ID       int     `json:"id"`
+		MemberID int     `json:"member_id"`
+		Division string  `json:"division"`
+		Score    int     `json:"score"`
+		Money    float64 `json:"money"`
+	}
+	divisions := []string{"engineering", "finance", "people", "business"}
+	accounts := make([]Account, 100000)
+	for i := range accounts {
+		accounts[i] = Account{
+			ID:       i,
+			MemberID: i % 50,
+			Division: divisions[i%len(divisions)],
+			Score:    i % 100,
+			Money:    float64(i * 1000),
+		}
+	}
+
+	query := "(division=engineering || division=finance) && score>=50 && money<50000000"
+	condition, _ := GenerateCondition(query)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		condition.FilterSliceParallel(context.Background(), accounts)
+	}
+}
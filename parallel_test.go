@@ -0,0 +1,154 @@
+package astvalidator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCondition_FilterSliceParallel(t *testing.T) {
+	type Account struct {
+		ID       int      `json:"id"`
+		Division string   `json:"division"`
+		Score    int      `json:"score"`
+		Limit    Provider `json:"limit"`
+	}
+	divisions := []string{"engineering", "finance", "people", "business"}
+	accounts := make([]Account, 10000)
+	for i := range accounts {
+		id := i
+		accounts[i] = Account{
+			ID:       i,
+			Division: divisions[i%len(divisions)],
+			Score:    i % 100,
+			Limit: func(ctx context.Context) (interface{}, error) {
+				if id%1000 == 777 {
+					return nil, errors.New("limit service unavailable")
+				}
+				return id % 10, nil
+			},
+		}
+	}
+	pointers := make([]*Account, len(accounts))
+	for i := range accounts {
+		pointers[i] = &accounts[i]
+	}
+	pointers[4321] = nil
+	documents := make([]map[string]interface{}, 100)
+	for i := range documents {
+		documents[i] = map[string]interface{}{"id": i, "score": i % 10}
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		query     string
+		data      interface{}
+		opts      []Option
+		wantIndex int
+		wantErrIs error
+	}{
+		{
+			name:  "Normal case - default workers",
+			query: `(division=engineering || division=finance) && score>=50`,
+			data:  accounts,
+		},
+		{
+			name:  "Normal case - small chunks",
+			query: `(division=engineering || division=finance) && score>=50`,
+			data:  accounts,
+			opts:  []Option{WithWorkers(3), WithChunkSize(7)},
+		},
+		{
+			name:  "Normal case - single worker",
+			query: `division=people`,
+			data:  &accounts,
+			opts:  []Option{WithWorkers(1), WithChunkSize(100)},
+		},
+		{
+			name:  "Normal case - maps",
+			query: `score>5 || id<3`,
+			data:  documents,
+			opts:  []Option{WithWorkers(4), WithChunkSize(9)},
+		},
+		{
+			name:  "Normal case - empty slice",
+			query: `id=1`,
+			data:  []Account{},
+		},
+		{
+			name:      "Error case - first failing element",
+			query:     `score>=0 && limit<5`,
+			data:      accounts,
+			opts:      []Option{WithWorkers(8), WithChunkSize(50)},
+			wantIndex: 777,
+		},
+		{
+			name:      "Error case - nil element",
+			query:     `id>=0`,
+			data:      pointers,
+			opts:      []Option{WithChunkSize(64)},
+			wantIndex: 4321,
+			wantErrIs: ErrNilPointer,
+		},
+		{
+			name:      "Error case - canceled",
+			ctx:       canceled,
+			query:     `id>=0`,
+			data:      accounts,
+			wantErrIs: context.Canceled,
+		},
+		{
+			name:      "Error case - not a slice",
+			query:     `id>=0`,
+			data:      accounts[0],
+			wantErrIs: ErrUnsupportedType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _ := GenerateCondition(tt.query)
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			gotResults, err := condition.FilterSliceParallel(ctx, tt.data, tt.opts...)
+			if tt.wantIndex > 0 {
+				var elementErr *ElementError
+				if !errors.As(err, &elementErr) || elementErr.Index != tt.wantIndex {
+					t.Fatalf("Condition.FilterSliceParallel() error = %v, want element %d", err, tt.wantIndex)
+				}
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Fatalf("Condition.FilterSliceParallel() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if tt.wantIndex > 0 || tt.wantErrIs != nil {
+				return
+			}
+			if err != nil {
+				t.Fatalf("Condition.FilterSliceParallel() error = %v", err)
+			}
+			wantResults, _ := condition.FilterSlice(tt.data)
+			if got, want := resultIDs(gotResults), resultIDs(wantResults); !reflect.DeepEqual(got, want) {
+				t.Errorf("Condition.FilterSliceParallel() ids = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func resultIDs(results interface{}) []int64 {
+	ids := []int64{}
+	rValue := reflect.ValueOf(results)
+	for i := 0; i < rValue.Len(); i++ {
+		elem := rValue.Index(i)
+		if elem.Kind() == reflect.Map {
+			ids = append(ids, elem.MapIndex(reflect.ValueOf("id")).Elem().Int())
+			continue
+		}
+		ids = append(ids, elem.FieldByName("ID").Int())
+	}
+	return ids
+}
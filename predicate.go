@@ -0,0 +1,133 @@
+package astvalidator
+
+import (
+	"context"
+	"reflect"
+)
+
+type Predicate[T any] struct {
+	program   *Program
+	condition Condition
+	isPointer bool
+}
+
+func Compile[T any](query string, opts ...Option) (Predicate[T], error) {
+	rType := reflect.TypeFor[T]()
+	elemType := rType
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() == reflect.Struct {
+		program, err := CompileFor(query, elemType, opts...)
+		if err != nil {
+			return Predicate[T]{}, err
+		}
+		return Predicate[T]{
+			program:   program,
+			condition: program.condition,
+			isPointer: rType.Kind() == reflect.Ptr,
+		}, nil
+	}
+	condition, err := GenerateCondition(query, opts...)
+	if err != nil {
+		return Predicate[T]{}, err
+	}
+	return Predicate[T]{condition: condition}, nil
+}
+
+func (p Predicate[T]) Match(value T) (bool, error) {
+	return p.MatchContext(context.Background(), value)
+}
+
+func (p Predicate[T]) MatchContext(ctx context.Context, value T) (bool, error) {
+	if p.program == nil {
+		return p.condition.ValidateContext(ctx, value)
+	}
+	if p.isPointer {
+		return p.program.ValidateContext(ctx, value)
+	}
+	return p.program.validateValue(ctx, reflect.ValueOf(&value).Elem(), nil)
+}
+
+func (p Predicate[T]) matcher(values []T, opts []Option) func(i int) (bool, error) {
+	switch {
+	case p.program == nil:
+		return func(i int) (bool, error) {
+			return p.condition.Validate(values[i], opts...)
+		}
+	case p.isPointer:
+		return func(i int) (bool, error) {
+			return p.program.Validate(values[i], opts...)
+		}
+	}
+	rValues := reflect.ValueOf(values)
+	return func(i int) (bool, error) {
+		return p.program.validateValue(context.Background(), rValues.Index(i), opts)
+	}
+}
+
+func (p Predicate[T]) Filter(values []T) ([]T, error) {
+	match := p.matcher(values, nil)
+	result := make([]T, 0, len(values))
+	for i, value := range values {
+		isValid, err := match(i)
+		if err != nil {
+			return nil, err
+		}
+		if isValid {
+			result = append(result, value)
+		}
+	}
+	return result, nil
+}
+
+func (p Predicate[T]) Any(values []T) (bool, error) {
+	match := p.matcher(values, nil)
+	for i := range values {
+		isValid, err := match(i)
+		if err != nil || isValid {
+			return isValid, err
+		}
+	}
+	return false, nil
+}
+
+func (p Predicate[T]) All(values []T) (bool, error) {
+	match := p.matcher(values, nil)
+	for i := range values {
+		isValid, err := match(i)
+		if err != nil || !isValid {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (p Predicate[T]) Count(values []T) (int, error) {
+	match := p.matcher(values, nil)
+	count := 0
+	for i := range values {
+		isValid, err := match(i)
+		if err != nil {
+			return 0, err
+		}
+		if isValid {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (p Predicate[T]) Find(values []T) (result T, found bool, err error) {
+	match := p.matcher(values, nil)
+	for i, value := range values {
+		isValid, err := match(i)
+		if err != nil {
+			return result, false, err
+		}
+		if isValid {
+			return value, true, nil
+		}
+	}
+	return result, false, nil
+}
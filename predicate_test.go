@@ -0,0 +1,109 @@
+package astvalidator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPredicate(t *testing.T) {
+	type Account struct {
+		ID       int    `json:"id"`
+		Division string `json:"division"`
+		Score    int    `json:"score"`
+	}
+	accounts := []Account{
+		{ID: 1, Division: "engineering", Score: 80},
+		{ID: 2, Division: "finance", Score: 40},
+		{ID: 3, Division: "engineering", Score: 95},
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantMatch []bool
+		wantCount int
+		wantAny   bool
+		wantAll   bool
+		wantFind  int
+	}{
+		{
+			name:      "Normal case - some match",
+			query:     `division=engineering && score>=90`,
+			wantMatch: []bool{false, false, true},
+			wantCount: 1,
+			wantAny:   true,
+			wantFind:  3,
+		},
+		{
+			name:      "Normal case - all match",
+			query:     `id>0`,
+			wantMatch: []bool{true, true, true},
+			wantCount: 3,
+			wantAny:   true,
+			wantAll:   true,
+			wantFind:  1,
+		},
+		{
+			name:      "Normal case - none match",
+			query:     `division=people`,
+			wantMatch: []bool{false, false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate, err := Compile[Account](tt.query)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			var want []Account
+			for i, account := range accounts {
+				if got, _ := predicate.Match(account); got != tt.wantMatch[i] {
+					t.Errorf("Predicate.Match(%d) = %v, want %v", account.ID, got, tt.wantMatch[i])
+				}
+				if tt.wantMatch[i] {
+					want = append(want, account)
+				}
+			}
+			if got, _ := predicate.Filter(accounts); len(got) != len(want) || (len(want) > 0 && !reflect.DeepEqual(got, want)) {
+				t.Errorf("Predicate.Filter() = %v, want %v", got, want)
+			}
+			if got, _ := predicate.Count(accounts); got != tt.wantCount {
+				t.Errorf("Predicate.Count() = %v, want %v", got, tt.wantCount)
+			}
+			if got, _ := predicate.Any(accounts); got != tt.wantAny {
+				t.Errorf("Predicate.Any() = %v, want %v", got, tt.wantAny)
+			}
+			if got, _ := predicate.All(accounts); got != tt.wantAll {
+				t.Errorf("Predicate.All() = %v, want %v", got, tt.wantAll)
+			}
+			got, found, _ := predicate.Find(accounts)
+			if found != (tt.wantFind > 0) || got.ID != tt.wantFind {
+				t.Errorf("Predicate.Find() = %v, %v, want id %v", got, found, tt.wantFind)
+			}
+		})
+	}
+}
+
+func TestPredicate_Types(t *testing.T) {
+	type Account struct {
+		ID int `json:"id"`
+	}
+
+	pointerPredicate, _ := Compile[*Account](`id=2`)
+	if got, _ := pointerPredicate.Filter([]*Account{{ID: 1}, {ID: 2}}); len(got) != 1 || got[0].ID != 2 {
+		t.Errorf("Predicate[*Account].Filter() = %v, want id 2", got)
+	}
+	if _, err := pointerPredicate.Match(nil); err != ErrNilPointer {
+		t.Errorf("Predicate[*Account].Match(nil) error = %v, want %v", err, ErrNilPointer)
+	}
+
+	mapPredicate, _ := Compile[map[string]interface{}](`id=2 || name=budi`)
+	count, err := mapPredicate.Count([]map[string]interface{}{{"id": 2}, {"name": "budi"}, {"id": 3}})
+	if err != nil || count != 2 {
+		t.Errorf("Predicate[map].Count() = %v, %v, want 2", count, err)
+	}
+
+	if _, err := Compile[Account](`brand=nike`); err == nil {
+		t.Errorf("Compile() error = nil, want unknown attribute")
+	}
+}
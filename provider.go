@@ -0,0 +1,40 @@
+package astvalidator
+
+import (
+	"context"
+	"reflect"
+)
+
+type Provider func(ctx context.Context) (interface{}, error)
+
+func (e *evaluation) provide(path string, value interface{}) (interface{}, error) {
+	var provider Provider
+	switch fn := value.(type) {
+	case Provider:
+		provider = fn
+	case func(context.Context) (interface{}, error):
+		provider = fn
+	default:
+		rValue := reflect.ValueOf(value)
+		if rValue.Kind() != reflect.Func || !rValue.Type().ConvertibleTo(providerType) {
+			return value, nil
+		}
+		provider = rValue.Convert(providerType).Interface().(Provider)
+	}
+	if provider == nil {
+		return nil, nil
+	}
+	if result, ok := e.provided[path]; ok {
+		return result.value, result.err
+	}
+	e.stats.ProviderCalls++
+	result, err := provider(e.ctx)
+	if e.provided == nil {
+		e.provided = make(map[string]providedValue)
+	}
+	e.provided[path] = providedValue{
+		value: result,
+		err:   err,
+	}
+	return result, err
+}
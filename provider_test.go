@@ -0,0 +1,136 @@
+package astvalidator
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCondition_ValidateContextProvider(t *testing.T) {
+	type Loader func(ctx context.Context) (interface{}, error)
+	type Member struct {
+		ID      int                                            `json:"id"`
+		Tier    Provider                                       `json:"tier"`
+		Score   func(ctx context.Context) (interface{}, error) `json:"score"`
+		Segment Loader                                         `json:"segment"`
+	}
+
+	var tierCalls, fraudCalls int
+	tier := Provider(func(ctx context.Context) (interface{}, error) {
+		tierCalls++
+		return "gold", nil
+	})
+	fraudScore := func(ctx context.Context) (interface{}, error) {
+		fraudCalls++
+		return 12.5, nil
+	}
+	failing := Provider(func(ctx context.Context) (interface{}, error) {
+		return nil, errors.New("lookup failed")
+	})
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	type args struct {
+		ctx   context.Context
+		query string
+		data  interface{}
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantIsValid    bool
+		wantErr        bool
+		wantTierCalls  int
+		wantFraudCalls int
+	}{
+		{
+			name: "Normal case - provider is memoized per evaluation",
+			args: args{
+				ctx:   context.Background(),
+				query: `id=1 && (tier=gold || tier=platinum)`,
+				data:  Member{ID: 1, Tier: tier},
+			},
+			wantIsValid:   true,
+			wantTierCalls: 1,
+		},
+		{
+			name: "Normal case - unreferenced provider is not called",
+			args: args{
+				ctx:   context.Background(),
+				query: `id=1`,
+				data:  Member{ID: 1, Tier: tier},
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Normal case - short circuit skips provider",
+			args: args{
+				ctx:   context.Background(),
+				query: `id=2 && tier=gold`,
+				data:  Member{ID: 1, Tier: tier},
+			},
+			wantIsValid: false,
+		},
+		{
+			name: "Normal case - provider in map document",
+			args: args{
+				ctx:   context.Background(),
+				query: `fraud_score<50 && fraud_score>10`,
+				data:  map[string]interface{}{"fraud_score": fraudScore},
+			},
+			wantIsValid:    true,
+			wantFraudCalls: 1,
+		},
+		{
+			name: "Normal case - unnamed and custom func types in struct fields",
+			args: args{
+				ctx:   context.Background(),
+				query: `score>10 && segment=retail`,
+				data: Member{
+					ID:    1,
+					Score: fraudScore,
+					Segment: func(ctx context.Context) (interface{}, error) {
+						return "retail", nil
+					},
+				},
+			},
+			wantIsValid:    true,
+			wantFraudCalls: 1,
+		},
+		{
+			name: "Error case - provider error",
+			args: args{
+				ctx:   context.Background(),
+				query: `tier=gold`,
+				data:  Member{ID: 1, Tier: failing},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error case - cancelled context",
+			args: args{
+				ctx:   cancelled,
+				query: `tier=gold`,
+				data:  Member{ID: 1, Tier: tier},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tierCalls, fraudCalls = 0, 0
+			condition, _ := GenerateCondition(tt.args.query)
+			gotIsValid, err := condition.ValidateContext(tt.args.ctx, tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Condition.ValidateContext() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotIsValid != tt.wantIsValid {
+				t.Errorf("Condition.ValidateContext() = %v, want %v", gotIsValid, tt.wantIsValid)
+			}
+			if tierCalls != tt.wantTierCalls || fraudCalls != tt.wantFraudCalls {
+				t.Errorf("Condition.ValidateContext() provider calls = %v/%v, want %v/%v", tierCalls, fraudCalls, tt.wantTierCalls, tt.wantFraudCalls)
+			}
+		})
+	}
+}
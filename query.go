@@ -0,0 +1,347 @@
+package astvalidator
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type Query struct {
+	Condition *Condition `json:"condition,omitempty"`
+	OrderBy   []OrderBy  `json:"order_by,omitempty"`
+	Limit     *int       `json:"limit,omitempty"`
+	Offset    int        `json:"offset,omitempty"`
+	Select    []string   `json:"select,omitempty"`
+}
+
+type OrderBy struct {
+	Attribute  string `json:"attribute"`
+	Descending bool   `json:"descending,omitempty"`
+}
+
+type sortKey struct {
+	rank      int
+	isInteger bool
+	integer   int64
+	number    float64
+	time      time.Time
+	text      string
+}
+
+const (
+	rankMissing = iota
+	rankNumeric
+	rankTime
+	rankBool
+	rankText
+)
+
+var clauses = []string{ClauseOrderBy, ClauseLimit, ClauseOffset, ClauseSelect}
+
+func ParseQuery(query string, opts ...Option) (*Query, error) {
+	q := &Query{}
+	positions := findClauses(query)
+	where := query
+	if len(positions) > 0 {
+		where = query[:positions[0].start]
+	}
+	if strings.TrimSpace(where) != "" {
+		condition, err := GenerateCondition(where, opts...)
+		if err != nil {
+			return nil, err
+		}
+		q.Condition = &condition
+	}
+	seen := make(map[string]bool)
+	for i, position := range positions {
+		end := len(query)
+		if i+1 < len(positions) {
+			end = positions[i+1].start
+		}
+		if seen[position.clause] {
+			return nil, &QueryError{Clause: position.clause, Reason: "declared more than once"}
+		}
+		seen[position.clause] = true
+		body := strings.TrimSpace(query[position.start+len(position.clause) : end])
+		if err := q.parseClause(position.clause, body); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+type clausePosition struct {
+	clause string
+	start  int
+}
+
+func findClauses(query string) []clausePosition {
+	var (
+		positions []clausePosition
+		depth     int
+		quote     rune
+	)
+	lower := strings.ToLower(query)
+	for i, char := range query {
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+			continue
+		case char == '\'' || char == '"':
+			quote = char
+			continue
+		case char == '(':
+			depth++
+			continue
+		case char == ')':
+			depth--
+			continue
+		}
+		if depth > 0 || (i > 0 && !unicode.IsSpace(rune(query[i-1])) && query[i-1] != ')') || expectsOperand(query[:i]) {
+			continue
+		}
+		for _, clause := range clauses {
+			if !hasKeyword(lower[i:], clause) {
+				continue
+			}
+			positions = append(positions, clausePosition{clause: clause, start: i})
+		}
+	}
+	return positions
+}
+
+func expectsOperand(text string) bool {
+	text = strings.TrimRightFunc(text, unicode.IsSpace)
+	return text != "" && strings.ContainsRune("=<>!&|:", rune(text[len(text)-1]))
+}
+
+func hasKeyword(text, keyword string) bool {
+	for _, word := range strings.Fields(keyword) {
+		if !strings.HasPrefix(text, word) {
+			return false
+		}
+		text = text[len(word):]
+		if len(text) > 0 && !unicode.IsSpace(rune(text[0])) {
+			return false
+		}
+		text = strings.TrimLeftFunc(text, unicode.IsSpace)
+	}
+	return true
+}
+
+func (q *Query) parseClause(clause, body string) error {
+	if body == "" {
+		return &QueryError{Clause: clause, Reason: "value is required"}
+	}
+	switch clause {
+	case ClauseOrderBy:
+		for _, term := range strings.Split(body, ",") {
+			fields := strings.Fields(term)
+			if len(fields) == 0 || len(fields) > 2 {
+				return &QueryError{Clause: clause, Reason: fmt.Sprintf("invalid term %q", strings.TrimSpace(term))}
+			}
+			orderBy := OrderBy{Attribute: fields[0]}
+			if len(fields) == 2 {
+				switch strings.ToLower(fields[1]) {
+				case OrderAscending:
+				case OrderDescending:
+					orderBy.Descending = true
+				default:
+					return &QueryError{Clause: clause, Reason: fmt.Sprintf("invalid direction %q", fields[1])}
+				}
+			}
+			q.OrderBy = append(q.OrderBy, orderBy)
+		}
+	case ClauseLimit, ClauseOffset:
+		value, err := strconv.Atoi(body)
+		if err != nil || value < 0 {
+			return &QueryError{Clause: clause, Reason: fmt.Sprintf("non-negative integer is required, got %q", body)}
+		}
+		if clause == ClauseLimit {
+			q.Limit = &value
+		} else {
+			q.Offset = value
+		}
+	case ClauseSelect:
+		for _, attribute := range strings.Split(body, ",") {
+			attribute = strings.TrimSpace(attribute)
+			if attribute == "" || strings.ContainsFunc(attribute, unicode.IsSpace) {
+				return &QueryError{Clause: clause, Reason: fmt.Sprintf("invalid attribute %q", attribute)}
+			}
+			q.Select = append(q.Select, attribute)
+		}
+	}
+	return nil
+}
+
+func (q *Query) Apply(data interface{}, opts ...Option) (result interface{}, err error) {
+	if data == nil {
+		return result, ErrNilData
+	}
+	data, err = indirect(data)
+	if err != nil {
+		return result, err
+	}
+	rType := reflect.TypeOf(data)
+	if rType.Kind() != reflect.Slice {
+		return result, newUnsupportedTypeError("slice", rType)
+	}
+	if q.Condition != nil {
+		data, err = q.Condition.FilterSlice(data, opts...)
+		if err != nil {
+			return result, err
+		}
+	}
+	rValue := reflect.ValueOf(data)
+	if len(q.OrderBy) > 0 {
+		rValue = q.sort(rValue)
+	}
+	rValue = q.paginate(rValue)
+	if len(q.Select) > 0 {
+		return q.project(rValue), nil
+	}
+	return rValue.Interface(), nil
+}
+
+func (q *Query) sort(rValue reflect.Value) reflect.Value {
+	length := rValue.Len()
+	keys := make([][]sortKey, length)
+	order := make([]int, length)
+	for i := range keys {
+		order[i] = i
+		keys[i] = make([]sortKey, len(q.OrderBy))
+		for j, orderBy := range q.OrderBy {
+			keys[i][j] = newSortKey(lookupPath(rValue.Index(i), orderBy.Attribute))
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		for j, orderBy := range q.OrderBy {
+			if result := compareSortKeys(keys[order[a]][j], keys[order[b]][j], orderBy.Descending); result != 0 {
+				return result < 0
+			}
+		}
+		return false
+	})
+	sorted := reflect.MakeSlice(rValue.Type(), length, length)
+	for i, index := range order {
+		sorted.Index(i).Set(rValue.Index(index))
+	}
+	return sorted
+}
+
+func (q *Query) paginate(rValue reflect.Value) reflect.Value {
+	start := min(q.Offset, rValue.Len())
+	end := rValue.Len()
+	if q.Limit != nil {
+		end = min(start+*q.Limit, end)
+	}
+	return rValue.Slice(start, end)
+}
+
+func (q *Query) project(rValue reflect.Value) []map[string]interface{} {
+	rows := make([]map[string]interface{}, rValue.Len())
+	for i := range rows {
+		row := make(map[string]interface{}, len(q.Select))
+		for _, attribute := range q.Select {
+			if value, found := lookupPath(rValue.Index(i), attribute); found {
+				row[attribute] = value
+			}
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+func newSortKey(value interface{}, found bool) sortKey {
+	if !found || value == nil {
+		return sortKey{rank: rankMissing}
+	}
+	switch value := value.(type) {
+	case time.Time:
+		return sortKey{rank: rankTime, time: value}
+	case json.Number:
+		if value == "" {
+			return sortKey{rank: rankMissing}
+		}
+		return numericSortKey(value.String())
+	case string:
+		switch getValueType(value) {
+		case TypeNumeric:
+			if key := numericSortKey(value); key.rank == rankNumeric {
+				return key
+			}
+		case TypeTime:
+			return sortKey{rank: rankTime, time: stringToTime(value)}
+		}
+		return sortKey{rank: rankText, text: value}
+	}
+	rValue := reflect.ValueOf(value)
+	switch rValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return sortKey{rank: rankNumeric, isInteger: true, integer: rValue.Int(), number: float64(rValue.Int())}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return sortKey{rank: rankNumeric, number: float64(rValue.Uint())}
+	case reflect.Float32, reflect.Float64:
+		return sortKey{rank: rankNumeric, number: rValue.Float()}
+	case reflect.Bool:
+		if rValue.Bool() {
+			return sortKey{rank: rankBool, integer: 1}
+		}
+		return sortKey{rank: rankBool}
+	case reflect.String:
+		return newSortKey(rValue.String(), true)
+	default:
+		return sortKey{rank: rankText, text: fmt.Sprint(value)}
+	}
+}
+
+func numericSortKey(value string) sortKey {
+	if integer, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return sortKey{rank: rankNumeric, isInteger: true, integer: integer, number: float64(integer)}
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return sortKey{rank: rankText, text: value}
+	}
+	return sortKey{rank: rankNumeric, number: number}
+}
+
+func compareSortKeys(a, b sortKey, descending bool) int {
+	switch {
+	case a.rank == rankMissing && b.rank == rankMissing:
+		return 0
+	case a.rank == rankMissing:
+		return 1
+	case b.rank == rankMissing:
+		return -1
+	}
+	result := cmp.Compare(a.rank, b.rank)
+	if result == 0 {
+		switch a.rank {
+		case rankNumeric:
+			if a.isInteger && b.isInteger {
+				result = cmp.Compare(a.integer, b.integer)
+			} else {
+				result = cmp.Compare(a.number, b.number)
+			}
+		case rankTime:
+			result = a.time.Compare(b.time)
+		case rankBool:
+			result = cmp.Compare(a.integer, b.integer)
+		default:
+			result = strings.Compare(a.text, b.text)
+		}
+	}
+	if descending {
+		return -result
+	}
+	return result
+}
@@ -0,0 +1,262 @@
+package astvalidator
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	limit := func(value int) *int {
+		return &value
+	}
+	tests := []struct {
+		name          string
+		query         string
+		want          *Query
+		wantCondition bool
+		wantValue     string
+		wantErrIs     error
+	}{
+		{
+			name:          "Normal case - all clauses",
+			query:         `(division=engineering || division=finance) && score>50 order by score desc, join_date asc limit 20 offset 40 select id, name`,
+			wantCondition: true,
+			want: &Query{
+				OrderBy: []OrderBy{{Attribute: "score", Descending: true}, {Attribute: "join_date"}},
+				Limit:   limit(20),
+				Offset:  40,
+				Select:  []string{"id", "name"},
+			},
+		},
+		{
+			name:  "Normal case - clauses only",
+			query: `ORDER BY name LIMIT 5`,
+			want: &Query{
+				OrderBy: []OrderBy{{Attribute: "name"}},
+				Limit:   limit(5),
+			},
+		},
+		{
+			name:          "Normal case - keywords inside values and groups are ignored",
+			query:         `(note='limit 3') && sort=limited`,
+			wantCondition: true,
+			want:          &Query{},
+		},
+		{
+			name:          "Normal case - keyword as a value",
+			query:         `name = select`,
+			wantCondition: true,
+			wantValue:     "select",
+			want:          &Query{},
+		},
+		{
+			name:          "Normal case - keyword as a value followed by its clause",
+			query:         `name = select select id`,
+			wantCondition: true,
+			wantValue:     "select",
+			want:          &Query{Select: []string{"id"}},
+		},
+		{
+			name:          "Normal case - keyword after an operator is not split off",
+			query:         `status = limit 5`,
+			wantCondition: true,
+			wantValue:     "limit5",
+			want:          &Query{},
+		},
+		{
+			name:      "Negative case - invalid direction",
+			query:     `score>1 order by score sideways`,
+			wantErrIs: ErrInvalidQuery,
+		},
+		{
+			name:      "Negative case - negative limit",
+			query:     `score>1 limit -1`,
+			wantErrIs: ErrInvalidQuery,
+		},
+		{
+			name:      "Negative case - empty select",
+			query:     `score>1 select`,
+			wantErrIs: ErrInvalidQuery,
+		},
+		{
+			name:      "Negative case - duplicated clause",
+			query:     `score>1 limit 1 limit 2`,
+			wantErrIs: ErrInvalidQuery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuery(tt.query)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("ParseQuery() error = %v, want %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseQuery() error = %v", err)
+			}
+			if (got.Condition != nil) != tt.wantCondition {
+				t.Errorf("ParseQuery() condition = %v, want condition %v", got.Condition, tt.wantCondition)
+			}
+			if tt.wantValue != "" && got.Condition.Conditions[0].Attribute.Value != tt.wantValue {
+				t.Errorf("ParseQuery() value = %q, want %q", got.Condition.Conditions[0].Attribute.Value, tt.wantValue)
+			}
+			got.Condition = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuery() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_Apply(t *testing.T) {
+	type Employee struct {
+		ID       int         `json:"id"`
+		Name     string      `json:"name"`
+		Division string      `json:"division"`
+		Score    float64     `json:"score"`
+		JoinDate time.Time   `json:"join_date"`
+		Manager  *Employee   `json:"manager"`
+		Rating   json.Number `json:"rating"`
+	}
+	date := func(day int) time.Time {
+		return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+	}
+	lead := &Employee{ID: 100, Name: "lead"}
+	employees := []Employee{
+		{ID: 1, Name: "alice", Division: "engineering", Score: 80, JoinDate: date(3), Rating: "9"},
+		{ID: 2, Name: "bob", Division: "finance", Score: 95, JoinDate: date(1), Manager: lead, Rating: "10"},
+		{ID: 3, Name: "carol", Division: "engineering", Score: 80, JoinDate: date(2), Rating: "7.5"},
+		{ID: 4, Name: "dave", Division: "people", Score: 60, JoinDate: date(4)},
+		{ID: 5, Name: "erin", Division: "engineering", Score: 95, JoinDate: date(5), Manager: lead, Rating: "8"},
+	}
+	documents := []map[string]interface{}{
+		{"id": 1, "amount": "100"},
+		{"id": 2, "amount": "9"},
+		{"id": 3},
+		{"id": 4, "amount": 25.5},
+		{"id": 5, "amount": "2024-01-02 00:00:00"},
+	}
+	ids := func(result interface{}) []int {
+		var got []int
+		rValue := reflect.ValueOf(result)
+		for i := 0; i < rValue.Len(); i++ {
+			id, _ := lookupPath(rValue.Index(i), "id")
+			got = append(got, id.(int))
+		}
+		return got
+	}
+	tests := []struct {
+		name      string
+		query     string
+		data      interface{}
+		wantIDs   []int
+		want      interface{}
+		wantErrIs error
+	}{
+		{
+			name:    "Normal case - filter, order and paginate",
+			query:   `score>=70 order by score desc, join_date asc limit 2 offset 1`,
+			data:    employees,
+			wantIDs: []int{5, 3},
+		},
+		{
+			name:    "Normal case - stable order for equal keys",
+			query:   `division=engineering order by score`,
+			data:    &employees,
+			wantIDs: []int{1, 3, 5},
+		},
+		{
+			name:    "Normal case - json number and nested attribute",
+			query:   `order by manager.id desc, rating desc`,
+			data:    employees,
+			wantIDs: []int{2, 5, 1, 3, 4},
+		},
+		{
+			name:    "Normal case - numeric strings sort numerically and missing values last",
+			query:   `order by amount`,
+			data:    documents,
+			wantIDs: []int{2, 4, 1, 5, 3},
+		},
+		{
+			name:    "Normal case - missing values stay last when descending",
+			query:   `order by amount desc`,
+			data:    documents,
+			wantIDs: []int{5, 1, 4, 2, 3},
+		},
+		{
+			name:  "Normal case - projection",
+			query: `division=engineering order by id desc limit 2 select id, name, manager.name`,
+			data:  employees,
+			want: []map[string]interface{}{
+				{"id": 5, "name": "erin", "manager.name": "lead"},
+				{"id": 3, "name": "carol"},
+			},
+		},
+		{
+			name:    "Normal case - zero limit",
+			query:   `order by id limit 0`,
+			data:    employees,
+			wantIDs: nil,
+		},
+		{
+			name:    "Normal case - offset past the end",
+			query:   `offset 10`,
+			data:    employees,
+			wantIDs: nil,
+		},
+		{
+			name:      "Negative case - nil data",
+			query:     `order by id`,
+			wantErrIs: ErrNilData,
+		},
+		{
+			name:      "Negative case - not a slice",
+			query:     `order by id`,
+			data:      employees[0],
+			wantErrIs: ErrUnsupportedType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery() error = %v", err)
+			}
+			got, err := query.Apply(tt.data)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("Apply() error = %v, want %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if tt.want != nil {
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Apply() = %v, want %v", got, tt.want)
+				}
+				return
+			}
+			if gotIDs := ids(got); !reflect.DeepEqual(gotIDs, tt.wantIDs) {
+				t.Errorf("Apply() ids = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+	var query Query
+	if err := json.Unmarshal([]byte(`{"order_by":[{"attribute":"id","descending":true}],"offset":1}`), &query); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	got, err := query.Apply(employees)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if gotIDs, wantIDs := ids(got), []int{4, 3, 2, 1}; !reflect.DeepEqual(gotIDs, wantIDs) {
+		t.Errorf("Apply() without limit ids = %v, want %v", gotIDs, wantIDs)
+	}
+}
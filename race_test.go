@@ -0,0 +1,5 @@
+//go:build race
+
+package astvalidator
+
+const raceEnabled = true
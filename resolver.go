@@ -0,0 +1,162 @@
+package astvalidator
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+)
+
+type AttributeResolver interface {
+	Resolve(path string) (value interface{}, found bool)
+}
+
+var attributeResolverType = reflect.TypeOf((*AttributeResolver)(nil)).Elem()
+
+type AttributeResolverFunc func(path string) (value interface{}, found bool)
+
+func (f AttributeResolverFunc) Resolve(path string) (interface{}, bool) {
+	return f(path)
+}
+
+type ValuesResolver url.Values
+
+func (v ValuesResolver) Resolve(path string) (interface{}, bool) {
+	values, ok := v[path]
+	if !ok || len(values) == 0 {
+		return nil, false
+	}
+	return values[0], true
+}
+
+type HeaderResolver http.Header
+
+func (h HeaderResolver) Resolve(path string) (interface{}, bool) {
+	values := http.Header(h).Values(path)
+	if len(values) == 0 {
+		return nil, false
+	}
+	return values[0], true
+}
+
+type EnvResolver string
+
+func (e EnvResolver) Resolve(path string) (interface{}, bool) {
+	return os.LookupEnv(string(e) + path)
+}
+
+type attributeComparer interface {
+	compareAttribute(e *evaluation, attribute *Attribute) (isValid bool, err error)
+}
+
+type aggregateResolver interface {
+	resolveAggregate(path string) (interface{}, bool)
+}
+
+type structResolver struct {
+	value reflect.Value
+}
+
+type documentResolver struct {
+	value reflect.Value
+}
+
+type namespaces map[string]interface{}
+
+func newResolver(data interface{}) (AttributeResolver, error) {
+	if resolver, ok := data.(AttributeResolver); ok {
+		return resolver, nil
+	}
+	rValue := reflect.ValueOf(data)
+	for rValue.Kind() == reflect.Ptr || rValue.Kind() == reflect.Interface {
+		if rValue.IsNil() {
+			return nil, ErrNilPointer
+		}
+		rValue = rValue.Elem()
+	}
+	switch rValue.Kind() {
+	case reflect.Map:
+		if rValue.Type().Key().Kind() != reflect.String {
+			return nil, newUnsupportedTypeError("string keyed map", rValue.Type())
+		}
+		if value, ok := rValue.Interface().(namespaces); ok {
+			return value, nil
+		}
+		return documentResolver{value: rValue}, nil
+	case reflect.Struct:
+		return structResolver{value: rValue}, nil
+	default:
+		return nil, newUnsupportedTypeError("struct", rValue.Type())
+	}
+}
+
+func (r structResolver) Resolve(path string) (interface{}, bool) {
+	return lookupPath(r.value, path)
+}
+
+func (r structResolver) compareAttribute(e *evaluation, attribute *Attribute) (isValid bool, err error) {
+	return r.comparePath(e, attribute.Name, attribute)
+}
+
+func (r structResolver) comparePath(e *evaluation, path string, attribute *Attribute) (isValid bool, err error) {
+	plan := getStructPlan(r.value.Type())
+	field, ok := plan.fields[path]
+	if !ok {
+		if !plan.hasPath(path) {
+			return false, newUnknownAttributeError(attribute)
+		}
+		value, found := lookupPath(r.value, path)
+		if !found {
+			if hasNullPrefix(r.value, path) {
+				return false, errNullValue
+			}
+			return false, newUnknownAttributeError(attribute)
+		}
+		return e.compareValue(value, attribute)
+	}
+	if field.compare == nil {
+		return e.compareValue(r.value.FieldByIndex(field.index).Interface(), attribute)
+	}
+	return field.compare(r.value.FieldByIndex(field.index), attribute)
+}
+
+func (r documentResolver) Resolve(path string) (interface{}, bool) {
+	return lookupPath(r.value, path)
+}
+
+func (n namespaces) Resolve(path string) (interface{}, bool) {
+	for key, value := range n {
+		if strings.HasPrefix(path, key+".") {
+			return lookupPath(reflect.ValueOf(value), path[len(key)+1:])
+		}
+	}
+	return nil, false
+}
+
+func (n namespaces) compareAttribute(e *evaluation, attribute *Attribute) (isValid bool, err error) {
+	err = newUnknownAttributeError(attribute)
+	for key, value := range n {
+		if len(key) > 0 && !strings.HasPrefix(attribute.Name, key) {
+			continue
+		}
+		rValue := reflect.ValueOf(value)
+		if rValue.Kind() != reflect.Struct {
+			return false, &TypeMismatchError{
+				Attribute: attribute.Name,
+				Want:      "struct",
+				Got:       rValue.Kind().String(),
+			}
+		}
+		prefix := key + "."
+		if strings.HasPrefix(attribute.Name, prefix) {
+			isValid, err = structResolver{value: rValue}.comparePath(e, attribute.Name[len(prefix):], attribute)
+		} else {
+			err = newUnknownAttributeError(attribute)
+		}
+		if err != nil || !isValid {
+			break
+		}
+	}
+	return
+}
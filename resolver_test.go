@@ -0,0 +1,114 @@
+package astvalidator
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+type memberResolver struct {
+	tier  string
+	calls int
+}
+
+func (m *memberResolver) Resolve(path string) (interface{}, bool) {
+	m.calls++
+	switch path {
+	case "member.tier":
+		return m.tier, true
+	case "member.age":
+		return 30, true
+	default:
+		return nil, false
+	}
+}
+
+func TestCondition_ValidateResolver(t *testing.T) {
+	t.Setenv("APP_REGION", "id")
+	t.Setenv("APP_WORKERS", "8")
+
+	header := http.Header{}
+	header.Set("X-Client-Version", "12")
+	header.Set("Content-Type", "application/json")
+
+	type args struct {
+		query    string
+		resolver AttributeResolver
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantIsValid bool
+		wantErr     bool
+	}{
+		{
+			name: "Normal case - resolver func",
+			args: args{
+				query: `score>=80 && division=engineering`,
+				resolver: AttributeResolverFunc(func(path string) (interface{}, bool) {
+					switch path {
+					case "score":
+						return 85.5, true
+					case "division":
+						return "engineering", true
+					}
+					return nil, false
+				}),
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Normal case - url values",
+			args: args{
+				query:    `page>1 && sort=name`,
+				resolver: ValuesResolver(url.Values{"page": {"2"}, "sort": {"name", "id"}}),
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Normal case - http header is case insensitive",
+			args: args{
+				query:    `x-client-version>=10 && Content-Type=application/json`,
+				resolver: HeaderResolver(header),
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Normal case - environment variables",
+			args: args{
+				query:    `REGION=id && WORKERS>4 && DEBUG=true`,
+				resolver: EnvResolver("APP_"),
+			},
+			wantIsValid: false,
+		},
+		{
+			name: "Normal case - custom resolver",
+			args: args{
+				query:    `member.tier=gold && member.age>18`,
+				resolver: &memberResolver{tier: "gold"},
+			},
+			wantIsValid: true,
+		},
+		{
+			name: "Error case - invalid numeric literal",
+			args: args{
+				query:    `member.age>abc`,
+				resolver: &memberResolver{tier: "gold"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _ := GenerateCondition(tt.args.query)
+			gotIsValid, err := condition.Validate(tt.args.resolver)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Condition.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotIsValid != tt.wantIsValid {
+				t.Errorf("Condition.Validate() = %v, want %v", gotIsValid, tt.wantIsValid)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package astvalidator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"iter"
+)
+
+type ErrorHandler func(err *ElementError) bool
+
+func WithErrorHandler(handler ErrorHandler) Option {
+	return func(e *evaluation) {
+		e.errorHandler = handler
+	}
+}
+
+func FilterSeq[T any](c *Condition, seq iter.Seq[T], opts ...Option) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		index := 0
+		for value := range seq {
+			isValid, err := c.Validate(value, opts...)
+			switch {
+			case err != nil:
+				if !yield(value, &ElementError{Index: index, Err: err}) {
+					return
+				}
+			case isValid:
+				if !yield(value, nil) {
+					return
+				}
+			}
+			index++
+		}
+	}
+}
+
+func FilterSeq2[K, V any](c *Condition, seq iter.Seq2[K, V], opts ...Option) (iter.Seq2[K, V], func() error) {
+	handler := c.resolveOptions(opts).errorHandler
+	var failure error
+	filtered := func(yield func(K, V) bool) {
+		failure = nil
+		index := 0
+		for key, value := range seq {
+			isValid, err := c.Validate(value, opts...)
+			switch {
+			case err != nil && handler == nil:
+				failure = &ElementError{Index: index, Err: err}
+				return
+			case err != nil:
+				if !handler(&ElementError{Index: index, Err: err}) {
+					return
+				}
+			case isValid:
+				if !yield(key, value) {
+					return
+				}
+			}
+			index++
+		}
+	}
+	return filtered, func() error { return failure }
+}
+
+func FilterChan[T any](ctx context.Context, c *Condition, in <-chan T, opts ...Option) (<-chan T, <-chan error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	handler := c.resolveOptions(opts).errorHandler
+	out := make(chan T)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(out)
+		defer close(errs)
+		for index := 0; ; index++ {
+			if err := ctx.Err(); err != nil {
+				errs <- err
+				return
+			}
+			var (
+				value T
+				ok    bool
+			)
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			case value, ok = <-in:
+				if !ok {
+					return
+				}
+			}
+			isValid, err := c.ValidateContext(ctx, value, opts...)
+			switch {
+			case err != nil && ctx.Err() != nil:
+			case err != nil && handler == nil:
+				errs <- &ElementError{Index: index, Err: err}
+				return
+			case err != nil:
+				if !handler(&ElementError{Index: index, Err: err}) {
+					return
+				}
+			case isValid:
+				select {
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				case out <- value:
+				}
+			}
+		}
+	}()
+	return out, errs
+}
+
+func (c *Condition) FilterNDJSON(ctx context.Context, reader io.Reader, writer io.Writer, opts ...Option) error {
+	if reader == nil || writer == nil {
+		return ErrNilData
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	handler := c.resolveOptions(opts).errorHandler
+	buffered := bufio.NewReader(reader)
+	for index := 0; ; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line, readErr := buffered.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+		if record := bytes.TrimSpace(line); len(record) > 0 {
+			isValid, err := c.validateRecord(ctx, record, opts)
+			switch {
+			case err != nil && handler == nil:
+				return &ElementError{Index: index, Err: err}
+			case err != nil:
+				if !handler(&ElementError{Index: index, Err: err}) {
+					return nil
+				}
+			case isValid:
+				if _, err := writer.Write(append(record, '\n')); err != nil {
+					return err
+				}
+			}
+			index++
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+func (c *Condition) validateRecord(ctx context.Context, record []byte, opts []Option) (bool, error) {
+	document, err := c.scanJSON(bytes.NewReader(record))
+	if err != nil {
+		return false, err
+	}
+	return c.ValidateContext(ctx, document, opts...)
+}
@@ -0,0 +1,360 @@
+package astvalidator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type streamAccount struct {
+	ID    int      `json:"id"`
+	Score int      `json:"score"`
+	Limit Provider `json:"limit"`
+}
+
+func streamAccounts(n int, failing ...int) []streamAccount {
+	accounts := make([]streamAccount, n)
+	for i := range accounts {
+		fail := slices.Contains(failing, i)
+		accounts[i] = streamAccount{
+			ID:    i,
+			Score: i % 10,
+			Limit: func(ctx context.Context) (interface{}, error) {
+				if fail {
+					return nil, errors.New("limit service unavailable")
+				}
+				return 1, nil
+			},
+		}
+	}
+	return accounts
+}
+
+func TestFilterSeq(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		data      []streamAccount
+		take      int
+		wantIDs   []int
+		wantErrAt []int
+	}{
+		{
+			name:    "Normal case",
+			query:   `score>=7`,
+			data:    streamAccounts(20),
+			wantIDs: []int{7, 8, 9, 17, 18, 19},
+		},
+		{
+			name:    "Normal case - early break",
+			query:   `score>=7`,
+			data:    streamAccounts(20),
+			take:    2,
+			wantIDs: []int{7, 8},
+		},
+		{
+			name:      "Negative case - element errors are yielded",
+			query:     `score>=7 && limit=1`,
+			data:      streamAccounts(20, 8, 15),
+			wantIDs:   []int{7, 9, 17, 18, 19},
+			wantErrAt: []int{8},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := GenerateCondition(tt.query)
+			if err != nil {
+				t.Fatalf("GenerateCondition() error = %v", err)
+			}
+			var gotIDs, gotErrAt []int
+			for value, err := range FilterSeq(&condition, slices.Values(tt.data)) {
+				if err != nil {
+					var elementErr *ElementError
+					if !errors.As(err, &elementErr) {
+						t.Fatalf("FilterSeq() error = %v, want *ElementError", err)
+					}
+					gotErrAt = append(gotErrAt, elementErr.Index)
+					continue
+				}
+				gotIDs = append(gotIDs, value.ID)
+				if len(gotIDs) == tt.take {
+					break
+				}
+			}
+			if !reflect.DeepEqual(gotIDs, tt.wantIDs) {
+				t.Errorf("FilterSeq() ids = %v, want %v", gotIDs, tt.wantIDs)
+			}
+			if !reflect.DeepEqual(gotErrAt, tt.wantErrAt) {
+				t.Errorf("FilterSeq() error indexes = %v, want %v", gotErrAt, tt.wantErrAt)
+			}
+		})
+	}
+}
+
+func TestFilterSeq2(t *testing.T) {
+	documents := []map[string]interface{}{
+		{"score": 3},
+		{"score": 8},
+		{"score": "high"},
+		{"score": 9},
+	}
+	tests := []struct {
+		name        string
+		query       string
+		opts        []Option
+		withHandler bool
+		wantKeys    []int
+		wantErrs    int
+		wantErrAt   int
+	}{
+		{
+			name:     "Normal case",
+			query:    `score>5`,
+			wantKeys: []int{1, 3},
+		},
+		{
+			name:        "Negative case - errors are reported to the handler",
+			query:       `score>5`,
+			opts:        []Option{WithMode(ModeStrict)},
+			withHandler: true,
+			wantKeys:    []int{1, 3},
+			wantErrs:    1,
+		},
+		{
+			name:      "Negative case - first error stops the sequence without a handler",
+			query:     `score>5`,
+			opts:      []Option{WithMode(ModeStrict)},
+			wantKeys:  []int{1},
+			wantErrAt: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := GenerateCondition(tt.query)
+			if err != nil {
+				t.Fatalf("GenerateCondition() error = %v", err)
+			}
+			var gotErrs int
+			opts := tt.opts
+			if tt.withHandler {
+				opts = append(opts, WithErrorHandler(func(err *ElementError) bool {
+					gotErrs++
+					return true
+				}))
+			}
+			var gotKeys []int
+			seq, seqErr := FilterSeq2(&condition, slices.All(documents), opts...)
+			for key := range seq {
+				gotKeys = append(gotKeys, key)
+			}
+			if !reflect.DeepEqual(gotKeys, tt.wantKeys) {
+				t.Errorf("FilterSeq2() keys = %v, want %v", gotKeys, tt.wantKeys)
+			}
+			if gotErrs != tt.wantErrs {
+				t.Errorf("FilterSeq2() errors = %d, want %d", gotErrs, tt.wantErrs)
+			}
+			var elementErr *ElementError
+			if err := seqErr(); tt.wantErrAt > 0 {
+				if !errors.As(err, &elementErr) || elementErr.Index != tt.wantErrAt {
+					t.Errorf("FilterSeq2() error = %v, want element error at %d", err, tt.wantErrAt)
+				}
+			} else if err != nil {
+				t.Errorf("FilterSeq2() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestFilterChan(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		data        []streamAccount
+		cancel      bool
+		withHandler bool
+		stopAt      int
+		wantIDs     []int
+		wantErrAt   []int
+		wantErrIs   error
+	}{
+		{
+			name:    "Normal case",
+			query:   `score=1`,
+			data:    streamAccounts(30),
+			wantIDs: []int{1, 11, 21},
+		},
+		{
+			name:      "Negative case - first element error stops the stream without a handler",
+			query:     `score=1 && limit=1`,
+			data:      streamAccounts(30, 1, 11),
+			wantErrAt: []int{1},
+		},
+		{
+			name:        "Negative case - element errors are reported to the handler",
+			query:       `score=1 && limit=1`,
+			data:        streamAccounts(30, 1, 11),
+			withHandler: true,
+			wantIDs:     []int{21},
+			wantErrAt:   []int{1, 11},
+		},
+		{
+			name:        "Negative case - handler stops the stream",
+			query:       `score=1 && limit=1`,
+			data:        streamAccounts(30, 11),
+			withHandler: true,
+			stopAt:      11,
+			wantIDs:     []int{1},
+			wantErrAt:   []int{11},
+		},
+		{
+			name:      "Negative case - canceled context",
+			query:     `score=1`,
+			data:      streamAccounts(30),
+			cancel:    true,
+			wantErrIs: context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := GenerateCondition(tt.query)
+			if err != nil {
+				t.Fatalf("GenerateCondition() error = %v", err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+			in := make(chan streamAccount)
+			go func() {
+				defer close(in)
+				for _, account := range tt.data {
+					select {
+					case in <- account:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+			var gotIDs, gotErrAt []int
+			var opts []Option
+			if tt.withHandler {
+				opts = append(opts, WithErrorHandler(func(err *ElementError) bool {
+					gotErrAt = append(gotErrAt, err.Index)
+					return err.Index != tt.stopAt || tt.stopAt == 0
+				}))
+			}
+			out, errs := FilterChan(ctx, &condition, in, opts...)
+			for value := range out {
+				gotIDs = append(gotIDs, value.ID)
+			}
+			gotErr := <-errs
+			var elementErr *ElementError
+			if errors.As(gotErr, &elementErr) && !tt.withHandler {
+				gotErrAt, gotErr = append(gotErrAt, elementErr.Index), nil
+			}
+			if tt.wantErrIs != nil {
+				if !errors.Is(gotErr, tt.wantErrIs) {
+					t.Errorf("FilterChan() error = %v, want %v", gotErr, tt.wantErrIs)
+				}
+				return
+			}
+			if gotErr != nil {
+				t.Fatalf("FilterChan() error = %v", gotErr)
+			}
+			if !reflect.DeepEqual(gotIDs, tt.wantIDs) {
+				t.Errorf("FilterChan() ids = %v, want %v", gotIDs, tt.wantIDs)
+			}
+			if !reflect.DeepEqual(gotErrAt, tt.wantErrAt) {
+				t.Errorf("FilterChan() error indexes = %v, want %v", gotErrAt, tt.wantErrAt)
+			}
+		})
+	}
+
+	condition, _ := GenerateCondition(`score=1`)
+	in := make(chan streamAccount, 1)
+	in <- streamAccount{ID: 1, Score: 1}
+	close(in)
+	out, _ := FilterChan(nil, &condition, in)
+	if value := <-out; value.ID != 1 {
+		t.Errorf("FilterChan(nil) = %v, want id 1", value.ID)
+	}
+}
+
+func TestCondition_FilterNDJSON(t *testing.T) {
+	input := `{"id":1,"user":{"tier":"gold"},"amount":120}
+{"id":2,"user":{"tier":"silver"},"amount":300}
+
+{"id":3,"user":{"tier":"gold"},"amount":40}
+{"id":4,"user":{"tier":"gold"
+{"id":5,"user":{"tier":"gold"},"amount":500}`
+	tests := []struct {
+		name      string
+		query     string
+		opts      []Option
+		noHandler bool
+		stopOnErr bool
+		want      string
+		wantErrAt []int
+	}{
+		{
+			name:  "Normal case",
+			query: `user.tier=gold && amount>100`,
+			want: `{"id":1,"user":{"tier":"gold"},"amount":120}
+{"id":5,"user":{"tier":"gold"},"amount":500}
+`,
+			wantErrAt: []int{3},
+		},
+		{
+			name:      "Negative case - handler stops the stream",
+			query:     `user.tier=gold && amount>100`,
+			stopOnErr: true,
+			want: `{"id":1,"user":{"tier":"gold"},"amount":120}
+`,
+			wantErrAt: []int{3},
+		},
+		{
+			name:      "Negative case - first error is returned without a handler",
+			query:     `user.tier=gold && amount>100`,
+			noHandler: true,
+			want: `{"id":1,"user":{"tier":"gold"},"amount":120}
+`,
+			wantErrAt: []int{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := GenerateCondition(tt.query)
+			if err != nil {
+				t.Fatalf("GenerateCondition() error = %v", err)
+			}
+			var gotErrAt []int
+			opts := tt.opts
+			if !tt.noHandler {
+				opts = append(opts, WithErrorHandler(func(err *ElementError) bool {
+					gotErrAt = append(gotErrAt, err.Index)
+					return !tt.stopOnErr
+				}))
+			}
+			var output bytes.Buffer
+			err = condition.FilterNDJSON(context.Background(), strings.NewReader(input), &output, opts...)
+			var elementErr *ElementError
+			if errors.As(err, &elementErr) && tt.noHandler {
+				gotErrAt, err = append(gotErrAt, elementErr.Index), nil
+			}
+			if err != nil {
+				t.Fatalf("FilterNDJSON() error = %v", err)
+			}
+			if got := output.String(); got != tt.want {
+				t.Errorf("FilterNDJSON() output = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotErrAt, tt.wantErrAt) {
+				t.Errorf("FilterNDJSON() error indexes = %v, want %v", gotErrAt, tt.wantErrAt)
+			}
+		})
+	}
+}
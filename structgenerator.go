@@ -2,6 +2,8 @@ package astvalidator
 
 import (
 	"bytes"
+	"strings"
+	"unicode"
 )
 
 var (
@@ -19,12 +21,14 @@ var (
 	}
 )
 
-func GenerateCondition(query string) (Condition, error) {
+func GenerateCondition(query string, opts ...Option) (Condition, error) {
 	tokenAttributes := getTokenAttributes(query)
 	if len(tokenAttributes) == 0 {
-		return Condition{Attribute: &Attribute{}}, nil
+		return Condition{Attribute: &Attribute{}, options: opts}, nil
 	}
 	_, condition := buildCondition(Condition{}, tokenAttributes)
+	condition.options = opts
+	condition.programs = &programCache{}
 	return condition, nil
 }
 
@@ -33,6 +37,7 @@ func buildCondition(condition Condition, attrs []*TokenAttribute) (int, Conditio
 		conditionItem *Condition
 		lastPos       int
 		operator      string
+		isAnnotation  bool
 	)
 	for i := 0; i < len(attrs); i++ {
 		lastPos = i
@@ -54,11 +59,30 @@ func buildCondition(condition Condition, attrs []*TokenAttribute) (int, Conditio
 			continue
 		}
 
+		if attr.value == AnnotationSyntax {
+			isAnnotation = true
+			continue
+		}
+		if isAnnotation {
+			isAnnotation = false
+			if conditionItem != nil {
+				if conditionItem.Attribute.Message != "" {
+					conditionItem.Attribute.Code = conditionItem.Attribute.Message
+				}
+				conditionItem.Attribute.Message = attr.value
+			}
+			continue
+		}
+
 		if val, ok := mapLogicalOperator[attr.value]; ok {
 			operator = val
 			conditionItem = nil
 		} else if _, ok := mapOperator[attr.value]; ok {
 			conditionItem.Attribute.Operator = attr.value
+		} else if isPredicate(attr.value) && conditionItem != nil && conditionItem.Attribute.Operator == "" {
+			conditionItem.Attribute.Operator = attr.value
+			conditionItem.Operator = operator
+			condition.Conditions = append(condition.Conditions, conditionItem)
 		} else {
 			if conditionItem == nil {
 				conditionItem = &Condition{
@@ -82,18 +106,86 @@ func buildCondition(condition Condition, attrs []*TokenAttribute) (int, Conditio
 	return lastPos, condition
 }
 
+func isPredicate(value string) bool {
+	return value == OperatorIsMissing || value == OperatorIsNotMissing
+}
+
+func getPredicate(runes []rune) (string, int) {
+	for _, predicate := range []string{OperatorIsNotMissing, OperatorIsMissing} {
+		if size := matchWords(runes, strings.Fields(predicate)); size > 0 {
+			return predicate, size
+		}
+	}
+	return "", 0
+}
+
+func matchWords(runes []rune, words []string) int {
+	i := 0
+	for _, word := range words {
+		start := i
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+		if i == start {
+			return 0
+		}
+		for _, char := range word {
+			if i >= len(runes) || unicode.ToLower(runes[i]) != char {
+				return 0
+			}
+			i++
+		}
+	}
+	if i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(")|&:", runes[i]) {
+		return 0
+	}
+	return i
+}
+
 func getTokenAttributes(query string) []*TokenAttribute {
 	tokenAttributes := []*TokenAttribute{}
 	buffer := &bytes.Buffer{}
 	isOpenQuote := false
-	for _, char := range query {
+	isOpenCall := false
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		char := runes[i]
+		if isOpenQuote && char != '"' {
+			buffer.WriteRune(char)
+			continue
+		}
+		if isOpenCall {
+			switch char {
+			case ' ', '\t', '\r', '\n':
+			case ')':
+				buffer.WriteRune(char)
+				tokenAttributes = appendAttribute(tokenAttributes, buffer, buffer.String())
+				isOpenCall = false
+			default:
+				buffer.WriteRune(char)
+			}
+			continue
+		}
+		if char == '(' && isAggregateFunction(buffer.String()) {
+			buffer.WriteRune(char)
+			isOpenCall = true
+			continue
+		}
 		switch char {
-		case ' ', '\n', '\'':
-			if !isOpenQuote {
+		case ' ', '\t', '\r', '\n':
+			predicate, size := getPredicate(runes[i:])
+			if size == 0 {
 				continue
-			} else {
-				buffer.WriteRune(char)
 			}
+			if buffer.Len() > 0 {
+				tokenAttributes = appendAttribute(tokenAttributes, buffer, buffer.String())
+			}
+			tokenAttributes = append(tokenAttributes, &TokenAttribute{
+				value: predicate,
+			})
+			i += size - 1
+		case '\'':
+			continue
 		case '|', '&', '<', '>':
 			if buffer.Len() > 0 {
 				bufBytes := buffer.Bytes()
@@ -109,6 +201,17 @@ func getTokenAttributes(query string) []*TokenAttribute {
 			} else {
 				buffer.WriteRune(char)
 			}
+		case ':':
+			if !isAnnotationAt(tokenAttributes, buffer, runes, i) {
+				tokenAttributes = appendRune(tokenAttributes, buffer, char)
+				continue
+			}
+			if buffer.Len() > 0 {
+				tokenAttributes = appendAttribute(tokenAttributes, buffer, buffer.String())
+			}
+			tokenAttributes = append(tokenAttributes, &TokenAttribute{
+				value: AnnotationSyntax,
+			})
 		case '=', '(', ')':
 			if buffer.Len() > 0 {
 				bufBytes := buffer.Bytes()
@@ -124,15 +227,15 @@ func getTokenAttributes(query string) []*TokenAttribute {
 				value: string(char),
 			})
 		case '"':
-			isOpenQuote = !isOpenQuote
-		default:
-			if buffer.Len() > 0 {
+			if !isOpenQuote && buffer.Len() > 0 {
 				bufByte := buffer.Bytes()[0]
 				if bufByte == ByteLessThan || bufByte == ByteGreaterThan {
-					tokenAttributes = appendAttribute(tokenAttributes, buffer, string(bufByte))
+					tokenAttributes = appendAttribute(tokenAttributes, buffer, buffer.String())
 				}
 			}
-			buffer.WriteRune(char)
+			isOpenQuote = !isOpenQuote
+		default:
+			tokenAttributes = appendRune(tokenAttributes, buffer, char)
 		}
 	}
 	if buffer.Len() > 0 {
@@ -141,6 +244,40 @@ func getTokenAttributes(query string) []*TokenAttribute {
 	return tokenAttributes
 }
 
+func appendRune(tokenAttributes []*TokenAttribute, buffer *bytes.Buffer, char rune) []*TokenAttribute {
+	if buffer.Len() > 0 {
+		bufByte := buffer.Bytes()[0]
+		if bufByte == ByteLessThan || bufByte == ByteGreaterThan {
+			tokenAttributes = appendAttribute(tokenAttributes, buffer, string(bufByte))
+		}
+	}
+	buffer.WriteRune(char)
+	return tokenAttributes
+}
+
+func isAnnotationAt(tokenAttributes []*TokenAttribute, buffer *bytes.Buffer, runes []rune, i int) bool {
+	isSeparated := i > 0 && unicode.IsSpace(runes[i-1]) ||
+		i+1 == len(runes) || unicode.IsSpace(runes[i+1]) || runes[i+1] == '"'
+	if !isSeparated {
+		return false
+	}
+	hasValue := buffer.Len() > 0
+	for j := len(tokenAttributes) - 1; j >= 0; j-- {
+		value := tokenAttributes[j].value
+		if _, ok := mapLogicalOperator[value]; ok || value == "(" || value == ")" {
+			return false
+		}
+		if _, ok := mapOperator[value]; ok {
+			return hasValue
+		}
+		if isPredicate(value) {
+			return true
+		}
+		hasValue = hasValue || value != AnnotationSyntax
+	}
+	return false
+}
+
 func appendAttribute(tokenAttributes []*TokenAttribute, buffer *bytes.Buffer, value string) []*TokenAttribute {
 	tokenAttributes = append(tokenAttributes, &TokenAttribute{
 		value: value,
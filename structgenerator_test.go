@@ -120,6 +120,46 @@ func TestGenerateConditionQueryStructure(t *testing.T) {
 			want:    `{"conditions":[{"conditions":[{"conditions":[{"attribute":{"name":"date","operator":"\u003c=","value":"2019-09-09"}},{"operator":"AND","attribute":{"name":"date","operator":"\u003e","value":"2019-08-08"}}]},{"operator":"OR","conditions":[{"attribute":{"name":"p_date","operator":"\u003e=","value":"2019-01-01"}},{"operator":"AND","attribute":{"name":"p_date","operator":"\u003c","value":"2019-02-02"}}]}]},{"operator":"AND","conditions":[{"attribute":{"name":"member_type","operator":"=","value":"1"}},{"operator":"OR","attribute":{"name":"member_type","operator":"=","value":"2"}}]}]}`,
 			wantErr: false,
 		},
+		{
+			name: "Normal case - rule message and code",
+			args: args{
+				query: `age >= 18 : "must be an adult, age >= 18" && (status = active : inactive_member : "member {field} is {value}")`,
+			},
+			want:    `{"conditions":[{"attribute":{"name":"age","operator":"\u003e=","value":"18","message":"must be an adult, age \u003e= 18"}},{"operator":"AND","conditions":[{"attribute":{"name":"status","operator":"=","value":"active","code":"inactive_member","message":"member {field} is {value}"}}]}]}`,
+			wantErr: false,
+		},
+		{
+			name: "Normal case - quoted time value",
+			args: args{
+				query: `join_date>"2015-01-01 00:00:00" : "joined too early"`,
+			},
+			want:    `{"conditions":[{"attribute":{"name":"join_date","operator":"\u003e","value":"2015-01-01 00:00:00","message":"joined too early"}}]}`,
+			wantErr: false,
+		},
+		{
+			name: "Normal case - colon inside a value is not an annotation",
+			args: args{
+				query: `t=10:30 && url=http://x : "bad url"`,
+			},
+			want:    `{"conditions":[{"attribute":{"name":"t","operator":"=","value":"10:30"}},{"operator":"AND","attribute":{"name":"url","operator":"=","value":"http://x","message":"bad url"}}]}`,
+			wantErr: false,
+		},
+		{
+			name: "Normal case - quoted value is literal",
+			args: args{
+				query: `note = "a && (b) : c=d" : "note mismatch"`,
+			},
+			want:    `{"conditions":[{"attribute":{"name":"note","operator":"=","value":"a \u0026\u0026 (b) : c=d","message":"note mismatch"}}]}`,
+			wantErr: false,
+		},
+		{
+			name: "Normal case - missing predicate",
+			args: args{
+				query: `brand is missing || (email is not missing : "email is set" && name = budi santoso)`,
+			},
+			want:    `{"conditions":[{"attribute":{"name":"brand","operator":"is missing","value":""}},{"operator":"OR","conditions":[{"attribute":{"name":"email","operator":"is not missing","value":"","message":"email is set"}},{"operator":"AND","attribute":{"name":"name","operator":"=","value":"budisantoso"}}]}]}`,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -381,6 +421,41 @@ func Test_getToken(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Normal case - missing predicate",
+			args: args{
+				value: "brand  is   missing||email is not missing && name = is missingno",
+			},
+			want: []*TokenAttribute{
+				{
+					value: "brand",
+				},
+				{
+					value: "is missing",
+				},
+				{
+					value: "||",
+				},
+				{
+					value: "email",
+				},
+				{
+					value: "is not missing",
+				},
+				{
+					value: "&&",
+				},
+				{
+					value: "name",
+				},
+				{
+					value: "=",
+				},
+				{
+					value: "ismissingno",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
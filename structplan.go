@@ -0,0 +1,303 @@
+package astvalidator
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type structPlan struct {
+	fields map[string]*fieldPlan
+}
+
+type fieldPlan struct {
+	index   []int
+	rType   reflect.Type
+	compare comparator
+}
+
+type comparator func(field reflect.Value, attribute *Attribute) (isValid bool, err error)
+
+var (
+	structPlans sync.Map
+
+	timeType       = reflect.TypeOf(time.Time{})
+	jsonNumberType = reflect.TypeOf(json.Number(""))
+	providerType   = reflect.TypeOf(Provider(nil))
+)
+
+func getStructPlan(rType reflect.Type) *structPlan {
+	if plan, ok := structPlans.Load(rType); ok {
+		return plan.(*structPlan)
+	}
+	plan := &structPlan{
+		fields: make(map[string]*fieldPlan),
+	}
+	plan.addFields("", nil, rType)
+	actual, _ := structPlans.LoadOrStore(rType, plan)
+	return actual.(*structPlan)
+}
+
+func (p *structPlan) addFields(prefix string, parentIndex []int, rType reflect.Type) {
+	for i := 0; i < rType.NumField(); i++ {
+		typeField := rType.Field(i)
+		if typeField.PkgPath != "" && !typeField.Anonymous {
+			continue
+		}
+		index := make([]int, len(parentIndex)+1)
+		copy(index, parentIndex)
+		index[len(parentIndex)] = i
+
+		tag := typeField.Name
+		jsonTag, hasTag := typeField.Tag.Lookup("json")
+		if hasTag && jsonTag != "" {
+			if name := strings.Split(jsonTag, ",")[0]; name != "" {
+				tag = name
+			}
+		}
+
+		isNestedStruct := typeField.Type.Kind() == reflect.Struct && typeField.Type != timeType
+		if isNestedStruct && typeField.Anonymous && !hasTag {
+			p.addFields(prefix, index, typeField.Type)
+			continue
+		}
+		if typeField.PkgPath != "" {
+			continue
+		}
+		if _, ok := p.fields[prefix+tag]; !ok {
+			p.fields[prefix+tag] = &fieldPlan{
+				index:   index,
+				rType:   typeField.Type,
+				compare: selectComparator(typeField.Type),
+			}
+		}
+		if isNestedStruct {
+			p.addFields(prefix+tag+".", index, typeField.Type)
+		}
+	}
+}
+
+func (p *structPlan) hasPath(path string) bool {
+	if _, ok := p.fields[path]; ok {
+		return true
+	}
+	for i := len(path) - 1; i > 0; i-- {
+		if path[i] != '.' {
+			continue
+		}
+		if field, ok := p.fields[path[:i]]; ok {
+			return hasTypePath(field.rType, path[i+1:])
+		}
+	}
+	return false
+}
+
+func hasTypePath(rType reflect.Type, path string) bool {
+	for len(path) > 0 {
+		segment := path
+		if index := strings.IndexByte(path, '.'); index >= 0 {
+			segment, path = path[:index], path[index+1:]
+		} else {
+			path = ""
+		}
+		for rType.Kind() == reflect.Ptr {
+			rType = rType.Elem()
+		}
+		switch rType.Kind() {
+		case reflect.Interface, reflect.Func:
+			return true
+		case reflect.Map:
+			if rType.Key().Kind() != reflect.String {
+				return false
+			}
+		case reflect.Slice, reflect.Array:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || rType.Kind() == reflect.Array && index >= rType.Len() {
+				return false
+			}
+		case reflect.Struct:
+			field, ok := getStructPlan(rType).fields[segment]
+			if !ok {
+				return false
+			}
+			rType = field.rType
+			continue
+		default:
+			return false
+		}
+		rType = rType.Elem()
+	}
+	return true
+}
+
+func selectComparator(rType reflect.Type) comparator {
+	if rType.Kind() == reflect.Ptr {
+		return comparePointer(selectComparator(rType.Elem()))
+	}
+	if rType.Kind() == reflect.Func && rType.ConvertibleTo(providerType) {
+		return nil
+	}
+	switch rType {
+	case timeType:
+		return compareTime
+	case jsonNumberType:
+		return compareJSONNumber
+	}
+	switch rType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compareUint
+	case reflect.Float32, reflect.Float64:
+		return compareFloat
+	case reflect.Bool:
+		return compareBool
+	case reflect.String:
+		return compareString
+	case reflect.Interface:
+		return compareDynamic
+	default:
+		return compareInterface
+	}
+}
+
+func comparePointer(compare comparator) comparator {
+	return func(field reflect.Value, attribute *Attribute) (bool, error) {
+		if field.IsNil() {
+			return false, errNullValue
+		}
+		return compare(field.Elem(), attribute)
+	}
+}
+
+func compareInt(field reflect.Value, attribute *Attribute) (bool, error) {
+	conditionValue, err := strconv.ParseInt(attribute.Value, 10, 64)
+	if err != nil {
+		return false, newParseError(attribute, "integer", err)
+	}
+	return validateInteger(field.Int(), attribute.Operator, conditionValue), nil
+}
+
+func compareUint(field reflect.Value, attribute *Attribute) (bool, error) {
+	conditionValue, err := strconv.ParseInt(attribute.Value, 10, 64)
+	if err != nil {
+		return false, newParseError(attribute, "integer", err)
+	}
+	if attribute.Operator == OperatorEqual {
+		return conditionValue >= 0 && field.Uint() == uint64(conditionValue), nil
+	}
+	return validateNumeric(float64(field.Uint()), attribute.Operator, conditionValue), nil
+}
+
+func compareFloat(field reflect.Value, attribute *Attribute) (bool, error) {
+	conditionValue, err := strconv.ParseFloat(attribute.Value, 64)
+	if err != nil {
+		return false, newParseError(attribute, "float", err)
+	}
+	if attribute.Operator == OperatorEqual {
+		return field.Float() == conditionValue, nil
+	}
+	return validateNumeric(field.Float(), attribute.Operator, conditionValue), nil
+}
+
+func compareTime(field reflect.Value, attribute *Attribute) (bool, error) {
+	conditionValue, err := parseTimeValue(attribute.Value)
+	if err != nil {
+		return false, newParseError(attribute, "time", err)
+	}
+	value, ok := field.Interface().(time.Time)
+	if !ok {
+		return false, nil
+	}
+	if attribute.Operator == OperatorEqual {
+		return value.Equal(conditionValue), nil
+	}
+	return validateTime(value, attribute.Operator, conditionValue), nil
+}
+
+func compareBool(field reflect.Value, attribute *Attribute) (bool, error) {
+	if attribute.Operator == OperatorEqual {
+		return field.Bool() == stringToBool(attribute.Value), nil
+	}
+	return false, newIncomparableError(attribute, "numeric or time", "bool")
+}
+
+func compareString(field reflect.Value, attribute *Attribute) (bool, error) {
+	if attribute.Operator == OperatorEqual {
+		return field.String() == attribute.Value, nil
+	}
+	switch conditionValueType(attribute.Value) {
+	case TypeTime:
+		conditionValue, err := parseTimeValue(attribute.Value)
+		if err != nil {
+			return false, newParseError(attribute, "time", err)
+		}
+		value, err := time.Parse(DateTimeFormat, field.String())
+		if err != nil {
+			return false, newIncomparableError(attribute, "time", "string")
+		}
+		return validateTime(value, attribute.Operator, conditionValue), nil
+	case TypeNumeric:
+		value, err := strconv.ParseFloat(field.String(), 64)
+		if err != nil {
+			return false, newIncomparableError(attribute, "numeric", "string")
+		}
+		return validateNumeric(value, attribute.Operator, stringToFloat64(attribute.Value)), nil
+	default:
+		return false, newIncomparableError(attribute, "numeric or time", "alphanumeric")
+	}
+}
+
+func conditionValueType(value string) int {
+	if isRelativeTime(value) {
+		return TypeTime
+	}
+	return getValueType(value)
+}
+
+func compareJSONNumber(field reflect.Value, attribute *Attribute) (bool, error) {
+	if value, err := strconv.ParseInt(field.String(), 10, 64); err == nil {
+		if conditionValue, err := strconv.ParseInt(attribute.Value, 10, 64); err == nil {
+			return validateInteger(value, attribute.Operator, conditionValue), nil
+		}
+	}
+	value, err := strconv.ParseFloat(field.String(), 64)
+	if err != nil {
+		return false, &ParseError{
+			Attribute: attribute.Name,
+			Value:     field.String(),
+			Want:      "number",
+			Err:       err,
+		}
+	}
+	conditionValue, err := strconv.ParseFloat(attribute.Value, 64)
+	if err != nil {
+		return false, newParseError(attribute, "number", err)
+	}
+	if attribute.Operator == OperatorEqual {
+		return value == conditionValue, nil
+	}
+	return validateNumeric(value, attribute.Operator, conditionValue), nil
+}
+
+func compareDynamic(field reflect.Value, attribute *Attribute) (bool, error) {
+	if field.IsNil() {
+		return false, errNullValue
+	}
+	value := field.Elem()
+	compare := selectComparator(value.Type())
+	if compare == nil {
+		return compareInterface(value, attribute)
+	}
+	return compare(value, attribute)
+}
+
+func compareInterface(field reflect.Value, attribute *Attribute) (bool, error) {
+	if attribute.Operator == OperatorEqual && field.CanInterface() {
+		return field.Interface() == interface{}(attribute.Value), nil
+	}
+	return false, newIncomparableError(attribute, "comparable value", field.Type().String())
+}
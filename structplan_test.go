@@ -0,0 +1,233 @@
+package astvalidator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetStructPlan(t *testing.T) {
+	type Profile struct {
+		Age      int       `json:"age"`
+		JoinDate time.Time `json:"join_date"`
+	}
+	type Audit struct {
+		CreatedBy string `json:"created_by"`
+	}
+	type Account struct {
+		Audit
+		ID       int     `json:"id,omitempty"`
+		Name     string
+		Profile  Profile `json:"profile"`
+		Manager  *Profile
+		internal string
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		wantIndex []int
+		wantFound bool
+	}{
+		{
+			name:      "Normal case - json tag with options",
+			path:      "id",
+			wantIndex: []int{1},
+			wantFound: true,
+		},
+		{
+			name:      "Normal case - field name without tag",
+			path:      "Name",
+			wantIndex: []int{2},
+			wantFound: true,
+		},
+		{
+			name:      "Normal case - nested struct path",
+			path:      "profile.age",
+			wantIndex: []int{3, 0},
+			wantFound: true,
+		},
+		{
+			name:      "Normal case - promoted field of embedded struct",
+			path:      "created_by",
+			wantIndex: []int{0, 0},
+			wantFound: true,
+		},
+		{
+			name:      "Normal case - time field is not expanded",
+			path:      "profile.join_date.wall",
+			wantFound: false,
+		},
+		{
+			name:      "Normal case - pointer struct is not expanded",
+			path:      "Manager.age",
+			wantFound: false,
+		},
+		{
+			name:      "Normal case - unexported field is ignored",
+			path:      "internal",
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, ok := getStructPlan(reflect.TypeOf(Account{})).fields[tt.path]
+			if ok != tt.wantFound {
+				t.Errorf("getStructPlan() found = %v, want %v", ok, tt.wantFound)
+				return
+			}
+			if ok && !reflect.DeepEqual(field.index, tt.wantIndex) {
+				t.Errorf("getStructPlan() index = %v, want %v", field.index, tt.wantIndex)
+			}
+		})
+	}
+}
+
+func TestCondition_ValidateStructPlan(t *testing.T) {
+	type Level int
+	type Profile struct {
+		Age   uint8 `json:"age"`
+		Level Level `json:"level"`
+	}
+	type Account struct {
+		ID      int         `json:"id,omitempty"`
+		Wallet  *float32    `json:"wallet"`
+		Active  bool        `json:"active"`
+		Profile Profile     `json:"profile"`
+		Meta    interface{} `json:"meta"`
+		Label   interface{} `json:"label"`
+		Note    interface{} `json:"note"`
+	}
+	wallet := float32(100.5)
+	object := Account{
+		ID:     1,
+		Wallet: &wallet,
+		Active: true,
+		Meta:   5,
+		Label:  "gold",
+		Profile: Profile{
+			Age:   30,
+			Level: 3,
+		},
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		wantIsValid bool
+		wantErr     bool
+	}{
+		{
+			name:        "Normal case - nested attribute",
+			query:       "id=1 && profile.age>18 && profile.level=3",
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - float pointer with decimal literal",
+			query:       "wallet=100.5 && active=true",
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - interface field compared by its dynamic type",
+			query:       "meta=5 && meta>3 && meta<6 && label=gold",
+			wantIsValid: true,
+		},
+		{
+			name:        "Negative case - nil interface field is unknown",
+			query:       "note=x || note>1",
+			wantIsValid: false,
+		},
+		{
+			name:        "Normal case - nested attribute not matched",
+			query:       "profile.age<18",
+			wantIsValid: false,
+		},
+		{
+			name:    "Error case - invalid numeric literal",
+			query:   "profile.level>abc",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _ := GenerateCondition(tt.query)
+			gotIsValid, err := condition.Validate(object)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Condition.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotIsValid != tt.wantIsValid {
+				t.Errorf("Condition.Validate() = %v, want %v", gotIsValid, tt.wantIsValid)
+			}
+			gotIsValid, _, err = condition.ValidateWithTrace(object)
+			if (err != nil) != tt.wantErr || gotIsValid != tt.wantIsValid {
+				t.Errorf("Condition.ValidateWithTrace() = %v, %v, want %v, wantErr %v", gotIsValid, err, tt.wantIsValid, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCondition_ValidateStringCoercion(t *testing.T) {
+	type Record struct {
+		Code      string `json:"code"`
+		Amount    string `json:"amount"`
+		CreatedAt string `json:"created_at"`
+	}
+	record := Record{Code: "abc", Amount: "150.5", CreatedAt: "2024-01-02 10:00:00"}
+	document := map[string]interface{}{"code": "abc", "amount": "150.5", "created_at": "2024-01-02 10:00:00"}
+
+	tests := []struct {
+		name        string
+		query       string
+		opts        []Option
+		wantIsValid bool
+		wantErr     bool
+	}{
+		{
+			name:        "Normal case - numeric string field against numeric literal",
+			query:       "amount>100 && amount<=150.5",
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - time string field against time literal",
+			query:       `created_at>"2024-01-01 00:00:00"`,
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - non numeric string field is false in lenient mode",
+			query:       "code>100",
+			wantIsValid: false,
+		},
+		{
+			name:    "Error case - non numeric string field in strict mode",
+			query:   "code>100",
+			opts:    []Option{WithMode(ModeStrict)},
+			wantErr: true,
+		},
+		{
+			name:    "Error case - alphanumeric literal in strict mode",
+			query:   "code>abc",
+			opts:    []Option{WithMode(ModeStrict)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _ := GenerateCondition(tt.query)
+			for name, data := range map[string]interface{}{"struct": record, "map": document} {
+				gotIsValid, err := condition.Validate(data, tt.opts...)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Condition.Validate(%s) error = %v, wantErr %v", name, err, tt.wantErr)
+					continue
+				}
+				if gotIsValid != tt.wantIsValid {
+					t.Errorf("Condition.Validate(%s) = %v, want %v", name, gotIsValid, tt.wantIsValid)
+				}
+				gotIsValid, _, err = condition.ValidateWithStats(data, tt.opts...)
+				if (err != nil) != tt.wantErr || gotIsValid != tt.wantIsValid {
+					t.Errorf("Condition.ValidateWithStats(%s) = %v, %v, want %v, wantErr %v", name, gotIsValid, err, tt.wantIsValid, tt.wantErr)
+				}
+			}
+		})
+	}
+}
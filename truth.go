@@ -0,0 +1,94 @@
+package astvalidator
+
+import (
+	"errors"
+)
+
+type Truth int8
+
+const (
+	TruthFalse Truth = iota
+	TruthTrue
+	TruthUnknown
+)
+
+type UnknownMapping int
+
+const (
+	UnknownAsFalse UnknownMapping = iota
+	UnknownAsTrue
+	UnknownAsError
+)
+
+var (
+	ErrUnknownResult = errors.New("condition result is unknown")
+
+	errNullValue = errors.New("null value")
+)
+
+func WithUnknownAs(mapping UnknownMapping) Option {
+	return func(e *evaluation) {
+		e.unknownAs = mapping
+	}
+}
+
+func TruthOf(isValid bool) Truth {
+	if isValid {
+		return TruthTrue
+	}
+	return TruthFalse
+}
+
+func (t Truth) And(other Truth) Truth {
+	switch {
+	case t == TruthFalse || other == TruthFalse:
+		return TruthFalse
+	case t == TruthUnknown || other == TruthUnknown:
+		return TruthUnknown
+	default:
+		return TruthTrue
+	}
+}
+
+func (t Truth) Or(other Truth) Truth {
+	switch {
+	case t == TruthTrue || other == TruthTrue:
+		return TruthTrue
+	case t == TruthUnknown || other == TruthUnknown:
+		return TruthUnknown
+	default:
+		return TruthFalse
+	}
+}
+
+func (t Truth) isDecided(operator string) bool {
+	if operator == LogicalOperatorOr {
+		return t == TruthTrue
+	}
+	return t == TruthFalse
+}
+
+func (t Truth) String() string {
+	switch t {
+	case TruthTrue:
+		return "true"
+	case TruthUnknown:
+		return "unknown"
+	default:
+		return "false"
+	}
+}
+
+func (e *evaluation) result(truth Truth) (bool, error) {
+	if truth != TruthUnknown {
+		return truth == TruthTrue, nil
+	}
+	switch e.unknownAs {
+	case UnknownAsTrue:
+		return true, nil
+	case UnknownAsError:
+		return false, ErrUnknownResult
+	default:
+		return false, nil
+	}
+}
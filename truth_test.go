@@ -0,0 +1,143 @@
+package astvalidator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTruth_And(t *testing.T) {
+	tests := []struct {
+		name  string
+		left  Truth
+		right Truth
+		want  Truth
+	}{
+		{name: "true and true", left: TruthTrue, right: TruthTrue, want: TruthTrue},
+		{name: "true and false", left: TruthTrue, right: TruthFalse, want: TruthFalse},
+		{name: "true and unknown", left: TruthTrue, right: TruthUnknown, want: TruthUnknown},
+		{name: "false and unknown", left: TruthFalse, right: TruthUnknown, want: TruthFalse},
+		{name: "unknown and unknown", left: TruthUnknown, right: TruthUnknown, want: TruthUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.left.And(tt.right); got != tt.want {
+				t.Errorf("Truth.And() = %v, want %v", got, tt.want)
+			}
+			if got := tt.right.And(tt.left); got != tt.want {
+				t.Errorf("Truth.And() reversed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruth_Or(t *testing.T) {
+	tests := []struct {
+		name  string
+		left  Truth
+		right Truth
+		want  Truth
+	}{
+		{name: "false or false", left: TruthFalse, right: TruthFalse, want: TruthFalse},
+		{name: "true or false", left: TruthTrue, right: TruthFalse, want: TruthTrue},
+		{name: "true or unknown", left: TruthTrue, right: TruthUnknown, want: TruthTrue},
+		{name: "false or unknown", left: TruthFalse, right: TruthUnknown, want: TruthUnknown},
+		{name: "unknown or unknown", left: TruthUnknown, right: TruthUnknown, want: TruthUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.left.Or(tt.right); got != tt.want {
+				t.Errorf("Truth.Or() = %v, want %v", got, tt.want)
+			}
+			if got := tt.right.Or(tt.left); got != tt.want {
+				t.Errorf("Truth.Or() reversed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCondition_ValidateUnknown(t *testing.T) {
+	type Member struct {
+		ID    int     `json:"id"`
+		Brand string  `json:"brand"`
+		Email *string `json:"email"`
+	}
+	type Account struct {
+		ID    int     `json:"id"`
+		Email *string `json:"email"`
+	}
+	email := "budi@mail.com"
+	account := Account{ID: 1}
+	document := map[string]interface{}{
+		"id":    1,
+		"email": nil,
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		opts        []Option
+		wantIsValid bool
+		wantErr     error
+	}{
+		{
+			name:        "Missing attribute in AND is unknown",
+			query:       `id=1 && brand=nike`,
+			wantIsValid: false,
+		},
+		{
+			name:        "Missing attribute in OR does not hide a true branch",
+			query:       `brand=nike || id=1`,
+			wantIsValid: true,
+		},
+		{
+			name:        "Missing attribute in AND mapped to true",
+			query:       `id=1 && brand=nike`,
+			opts:        []Option{WithUnknownAs(UnknownAsTrue)},
+			wantIsValid: true,
+		},
+		{
+			name:        "False operand decides over unknown",
+			query:       `id=2 && brand=nike`,
+			opts:        []Option{WithUnknownAs(UnknownAsTrue)},
+			wantIsValid: false,
+		},
+		{
+			name:    "Unknown result mapped to error",
+			query:   `id=1 && email=budi@mail.com`,
+			opts:    []Option{WithUnknownAs(UnknownAsError)},
+			wantErr: ErrUnknownResult,
+		},
+		{
+			name:        "Null attribute is missing",
+			query:       `id=1 && email is missing`,
+			opts:        []Option{WithUnknownAs(UnknownAsError)},
+			wantIsValid: true,
+		},
+		{
+			name:        "Unknown attribute is missing",
+			query:       `brand is missing && id is not missing`,
+			wantIsValid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _ := GenerateCondition(tt.query)
+			for _, data := range []interface{}{account, &account, document} {
+				isValid, err := condition.Validate(data, tt.opts...)
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Condition.Validate(%T) error = %v, wantErr %v", data, err, tt.wantErr)
+					continue
+				}
+				if isValid != tt.wantIsValid {
+					t.Errorf("Condition.Validate(%T) = %v, want %v", data, isValid, tt.wantIsValid)
+				}
+			}
+		})
+	}
+
+	condition, _ := GenerateCondition(`email is not missing`)
+	isValid, err := condition.Validate(Member{ID: 1, Email: &email})
+	if err != nil || !isValid {
+		t.Errorf("Condition.Validate() = %v, %v, want true", isValid, err)
+	}
+}
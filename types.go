@@ -4,12 +4,16 @@ type Condition struct {
 	Operator   string       `json:"operator,omitempty"`
 	Attribute  *Attribute   `json:"attribute,omitempty"`
 	Conditions []*Condition `json:"conditions,omitempty"`
+	options    []Option
+	programs   *programCache
 }
 
 type Attribute struct {
 	Name     string `json:"name"`
 	Operator string `json:"operator"`
 	Value    string `json:"value"`
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message,omitempty"`
 }
 
 type TokenAttribute struct {
@@ -0,0 +1,105 @@
+package astvalidator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type FieldError struct {
+	Field    string      `json:"field"`
+	Code     string      `json:"code"`
+	Message  string      `json:"message"`
+	Value    interface{} `json:"value,omitempty"`
+	Operator string      `json:"operator"`
+	Limit    string      `json:"limit"`
+}
+
+type ValidationErrors []*FieldError
+
+var (
+	mapOperatorErrorCode = map[string]string{
+		OperatorEqual:            ErrorCodeEqual,
+		OperatorLessThan:         ErrorCodeLessThan,
+		OperatorLessThanEqual:    ErrorCodeLessThanEqual,
+		OperatorGreaterThan:      ErrorCodeGreaterThan,
+		OperatorGreaterThanEqual: ErrorCodeGreaterThanEqual,
+	}
+)
+
+func (c *Condition) Check(data interface{}, opts ...Option) error {
+	e, err := c.newEvaluation(context.Background(), data, opts)
+	if err != nil {
+		return err
+	}
+	e.tracing = true
+	e.exhaustive = true
+	truth, err := c.validateAttribute(e)
+	if err != nil {
+		return err
+	}
+	isValid, err := e.result(truth)
+	if err != nil || isValid {
+		return err
+	}
+	return e.trace.fieldErrors(e, ValidationErrors{})
+}
+
+func (t *Trace) fieldErrors(e *evaluation, errs ValidationErrors) ValidationErrors {
+	if t.Result || t.ShortCircuited {
+		return errs
+	}
+	if t.Attribute == nil {
+		for _, subTrace := range t.Conditions {
+			errs = subTrace.fieldErrors(e, errs)
+		}
+		return errs
+	}
+	return append(errs, e.newFieldError(t))
+}
+
+func (e *evaluation) newFieldError(t *Trace) *FieldError {
+	fieldError := &FieldError{
+		Field:    t.Attribute.Name,
+		Code:     t.Attribute.Code,
+		Message:  t.Attribute.Message,
+		Value:    t.Value,
+		Operator: t.Attribute.Operator,
+		Limit:    t.Attribute.Value,
+	}
+	if fieldError.Code == "" {
+		fieldError.Code = mapOperatorErrorCode[t.Attribute.Operator]
+		if t.Unknown {
+			fieldError.Code = ErrorCodeMissing
+		}
+	}
+	fieldError.Message = fieldError.render(e.message(fieldError.Code, t.Attribute.Code, fieldError.Message))
+	return fieldError
+}
+
+func (f *FieldError) render(template string) string {
+	value := ""
+	if f.Value != nil {
+		value = fmt.Sprint(f.Value)
+	}
+	return strings.NewReplacer(
+		"{field}", f.Field,
+		"{value}", value,
+		"{limit}", f.Limit,
+	).Replace(template)
+}
+
+func (f *FieldError) Error() string {
+	return f.Message
+}
+
+func (v ValidationErrors) Error() string {
+	if len(v) == 0 {
+		return "validation failed"
+	}
+	messages := make([]string, len(v))
+	for i, fieldError := range v {
+		messages[i] = fieldError.Error()
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,77 @@
+package astvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCondition_Check(t *testing.T) {
+	type Request struct {
+		Name   string  `json:"name"`
+		Age    int     `json:"age"`
+		Amount float64 `json:"amount"`
+		Email  *string `json:"email"`
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		data       interface{}
+		wantErrors ValidationErrors
+		wantErr    bool
+	}{
+		{
+			name:  "Normal case - valid request",
+			query: `age >= 18 : "must be an adult" && amount > 0`,
+			data:  Request{Name: "Budi", Age: 20, Amount: 100},
+		},
+		{
+			name:  "Normal case - every failed rule is reported",
+			query: `age >= 18 : "must be an adult" && amount > 0 : invalid_amount : "{field} must be positive, got {value}" && name = Budi`,
+			data:  Request{Name: "Budi", Age: 17, Amount: -5},
+			wantErrors: ValidationErrors{
+				{Field: "age", Code: ErrorCodeGreaterThanEqual, Message: "must be an adult", Value: 17, Operator: ">=", Limit: "18"},
+				{Field: "amount", Code: "invalid_amount", Message: "amount must be positive, got -5", Value: float64(-5), Operator: ">", Limit: "0"},
+			},
+		},
+		{
+			name:  "Normal case - default message and null attribute",
+			query: `age < 60 && email = x@y.z`,
+			data:  Request{Age: 65},
+			wantErrors: ValidationErrors{
+				{Field: "age", Code: ErrorCodeLessThan, Message: "age must be less than 60", Value: 65, Operator: "<", Limit: "60"},
+				{Field: "email", Code: ErrorCodeMissing, Message: "email is required", Operator: "=", Limit: "x@y.z"},
+			},
+		},
+		{
+			name:  "Normal case - passing alternative is not reported",
+			query: `(age >= 18 || name = Budi) && amount > 0`,
+			data:  Request{Name: "Budi", Age: 10, Amount: 0},
+			wantErrors: ValidationErrors{
+				{Field: "amount", Code: ErrorCodeGreaterThan, Message: "amount must be greater than 0", Value: float64(0), Operator: ">", Limit: "0"},
+			},
+		},
+		{
+			name:    "Error case - invalid literal",
+			query:   `age >= abc`,
+			data:    Request{Age: 10},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _ := GenerateCondition(tt.query)
+			err := condition.Check(tt.data)
+			var gotErrors ValidationErrors
+			isValidationError := errors.As(err, &gotErrors)
+			if (err != nil && !isValidationError) != tt.wantErr {
+				t.Errorf("Condition.Check() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotErrors, tt.wantErrors) {
+				t.Errorf("Condition.Check() = %v, want %v", gotErrors, tt.wantErrors)
+			}
+		})
+	}
+}
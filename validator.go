@@ -1,31 +1,23 @@
 package astvalidator
 
 import (
-	"errors"
-	"fmt"
+	"context"
 	"reflect"
-	"strconv"
-	"strings"
 	"time"
 )
 
-func (c *Condition) Validate(data interface{}) (isValid bool, err error) {
-	if data == nil {
-		return false, fmt.Errorf(ErrorMessageInvalidData, "nil")
-	}
-	rType := reflect.TypeOf(data)
-	switch rType.Kind() {
-	case reflect.Struct, reflect.Map:
-		isValid, _, err := c.validateAttribute(rType, data)
-		return isValid, err
-	default:
-		return false, fmt.Errorf(ErrorMessageInvalidType, "struct")
-	}
+func (c *Condition) Validate(data interface{}, opts ...Option) (isValid bool, err error) {
+	return c.ValidateContext(context.Background(), data, opts...)
+}
+
+func (c *Condition) ValidateContext(ctx context.Context, data interface{}, opts ...Option) (isValid bool, err error) {
+	isValid, _, err = c.validate(ctx, data, opts)
+	return
 }
 
 func (c *Condition) ValidateObjects(data ... interface{}) (isValid bool, err error) {
 	if data == nil {
-		return false, fmt.Errorf(ErrorMessageInvalidData, "nil")
+		return false, ErrNilData
 	}
 	rType := reflect.TypeOf(data)
 	switch rType.Kind() {
@@ -36,24 +28,31 @@ func (c *Condition) ValidateObjects(data ... interface{}) (isValid bool, err err
 		}
 		return c.Validate(preparedData)
 	default:
-		return false, fmt.Errorf(ErrorMessageInvalidType, "slice")
+		return false, newUnsupportedTypeError("slice", rType)
 	}
 }
 
-func (c *Condition) FilterSlice(data interface{}) (result interface{}, err error) {
+func (c *Condition) FilterSlice(data interface{}, opts ...Option) (result interface{}, err error) {
 	if data == nil {
-		return result, fmt.Errorf(ErrorMessageInvalidData, "nil")
+		return result, ErrNilData
+	}
+	data, err = indirect(data)
+	if err != nil {
+		return result, err
 	}
 	rType := reflect.TypeOf(data)
 	switch rType.Kind() {
 	case reflect.Slice:
 		rValue := reflect.ValueOf(data)
+		if c.programs != nil && isStructElem(rType.Elem()) {
+			return c.filterCompiled(rValue, opts)
+		}
 		rSlice := reflect.MakeSlice(rType, 0, 1)
 		for i := 0; i < rValue.Len(); i++ {
 			obj := rValue.Index(i).Interface()
-			isValid, err := c.Validate(obj)
+			isValid, err := c.Validate(obj, opts...)
 			if err != nil {
-				return rSlice, err
+				return result, err
 			}
 			if isValid {
 				rSlice = reflect.Append(rSlice, rValue.Index(i))
@@ -62,174 +61,199 @@ func (c *Condition) FilterSlice(data interface{}) (result interface{}, err error
 		result = rSlice.Interface()
 		return
 	default:
-		return result, fmt.Errorf(ErrorMessageInvalidType, "slice")
+		return result, newUnsupportedTypeError("slice", rType)
+	}
+}
+
+func (c *Condition) filterCompiled(rValue reflect.Value, opts []Option) (interface{}, error) {
+	match, release := c.elementMatcher(context.Background(), rValue, opts)
+	defer release()
+
+	rSlice := reflect.MakeSlice(rValue.Type(), rValue.Len(), rValue.Len())
+	count := 0
+	for i := 0; i < rValue.Len(); i++ {
+		isValid, err := match(i)
+		if err != nil {
+			return nil, err
+		}
+		if isValid {
+			rSlice.Index(count).Set(rValue.Index(i))
+			count++
+		}
 	}
+	return rSlice.Slice(0, count).Interface(), nil
+}
+
+func (c *Condition) elementMatcher(ctx context.Context, rValue reflect.Value, opts []Option) (match func(i int) (bool, error), release func()) {
+	elemType := rValue.Type().Elem()
+	if c.programs == nil || !isStructElem(elemType) {
+		return func(i int) (bool, error) {
+			return c.ValidateContext(ctx, rValue.Index(i).Interface(), opts...)
+		}, func() {}
+	}
+	isPointer := elemType.Kind() == reflect.Ptr
+	if isPointer {
+		elemType = elemType.Elem()
+	}
+	evaluate := c.compiledFor(elemType)
+	e := c.acquireEvaluation(ctx, reflect.Value{}, opts)
+	return func(i int) (bool, error) {
+		elem := rValue.Index(i)
+		if isPointer {
+			if elem.IsNil() {
+				return false, ErrNilPointer
+			}
+			elem = elem.Elem()
+		}
+		e.root.value = elem
+		e.provided = nil
+		truth, err := evaluate(e, elem)
+		if err != nil {
+			return false, err
+		}
+		return e.result(truth)
+	}, e.release
+}
+
+func isStructElem(rType reflect.Type) bool {
+	if rType.Implements(attributeResolverType) {
+		return false
+	}
+	if rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	return rType.Kind() == reflect.Struct
 }
 
 func (c *Condition) prepareDataFromSlice(data interface{}) (interface{}, error) {
 	var preparedData interface{}
 	rValue := reflect.ValueOf(data)
 	if rValue.Type().Kind() != reflect.Slice {
-		return false, fmt.Errorf(ErrorMessageInvalidType, "slice")
+		return false, newUnsupportedTypeError("slice", rValue.Type())
 	}
 	if rValue.Len() == 0 {
-		return false, fmt.Errorf(ErrorMessageInvalidData, "empty slice")
+		return false, ErrEmptyData
 	}
 
 	firstValue := rValue.Index(0).Interface()
-	rFirstValue := reflect.ValueOf(firstValue)
 	if firstValue == nil {
-		return false, fmt.Errorf(ErrorMessageInvalidData, "nil")
+		return false, ErrNilData
 	}
+	firstValue, err := indirect(firstValue)
+	if err != nil {
+		return false, err
+	}
+	rFirstValue := reflect.ValueOf(firstValue)
 	switch rFirstValue.Type().Kind() {
 	case reflect.Struct:
 		preparedData = firstValue
-	default:
+	case reflect.Slice, reflect.Array:
 		length := rFirstValue.Len()
 		switch length {
 		case 0:
-			return false, fmt.Errorf(ErrorMessageInvalidData, "empty slice")
+			return false, ErrEmptyData
 		case 1:
 			preparedData = rFirstValue.Index(0).Interface()
 		default:
-			mapObj := make(map[string]interface{})
-			mapValue := reflect.MakeMap(reflect.TypeOf(mapObj))
+			mapObj := make(namespaces, length)
 			for i := 0; i < length; i++ {
-				rDetailValue := reflect.ValueOf(rFirstValue.Index(i).Interface())
-				mapValue.SetMapIndex(reflect.ValueOf(rDetailValue.Type().Name()), rDetailValue)
-			}
-			preparedData = mapValue.Interface()
-		}
-	}
-	return preparedData, nil
-}
-
-func (c *Condition) validateAttribute(rType reflect.Type, data interface{}) (isValid, isSkip bool, err error) {
-	if len(c.Conditions) > 0 {
-		for i, subCondition := range c.Conditions {
-			isSubValid, isSkip, err := subCondition.validateAttribute(rType, data)
-			if err != nil {
-				return false, false, err
-			}
-			if isSkip {
-				continue
-			}
-			if i == 0 {
-				isValid = isSubValid
-			} else {
-				if subCondition.Operator == LogicalOperatorOr {
-					isValid = isValid || isSubValid
-				} else {
-					isValid = isValid && isSubValid
+				detailValue, err := indirect(rFirstValue.Index(i).Interface())
+				if err != nil {
+					return false, err
 				}
+				mapObj[reflect.TypeOf(detailValue).Name()] = detailValue
 			}
+			preparedData = mapObj
 		}
-	} else {
-		switch rType.Kind() {
-		case reflect.Map:
-			if value, ok := data.(map[string]interface{}); ok {
-				isValid, isSkip, err = c.validateMapValue(value)
-			} else {
-				return false, false, errors.New(ErrorMessageUnableToCastObject)
-			}
-		default:
-			isValid, err = c.validateStructValue("", data)
-		}
+	default:
+		return false, newUnsupportedTypeError("struct", rFirstValue.Type())
 	}
-	return
+	return preparedData, nil
 }
 
-func (c *Condition) validateStructValue(prefix string, data interface{}) (isValid bool, err error) {
+func indirect(data interface{}) (interface{}, error) {
 	rValue := reflect.ValueOf(data)
-	if rValue.Type().Kind() != reflect.Struct {
-		return false, fmt.Errorf(ErrorMessageInvalidType, "struct")
+	if rValue.Kind() != reflect.Ptr && rValue.Kind() != reflect.Interface {
+		return data, nil
 	}
-	for i := 0; i < rValue.NumField(); i++ {
-		field := rValue.Field(i)
-		typeField := rValue.Type().Field(i)
-		tag := typeField.Name
-		jsonTag, ok := typeField.Tag.Lookup("json")
-		if ok && jsonTag != "" {
-			tag = jsonTag
+	for rValue.Kind() == reflect.Ptr || rValue.Kind() == reflect.Interface {
+		if rValue.IsNil() {
+			return nil, ErrNilPointer
 		}
-		tag = prefix + tag
+		rValue = rValue.Elem()
+	}
+	return rValue.Interface(), nil
+}
 
-		if tag == c.Attribute.Name {
-			var conditionValue interface{}
-			validationType := TypeNumeric
-			value := field.Interface()
-			operator := c.Attribute.Operator
+func (c *Condition) ValidateWithStats(data interface{}, opts ...Option) (isValid bool, stats EvaluationStats, err error) {
+	return c.validate(context.Background(), data, opts)
+}
 
-			if field.Kind() == reflect.Ptr && field.IsNil() {
-				return false, nil
-			}
+func (c *Condition) validate(ctx context.Context, data interface{}, opts []Option) (isValid bool, stats EvaluationStats, err error) {
+	if rValue, ok := structValue(data); ok && c.programs != nil {
+		e := c.acquireEvaluation(ctx, rValue, opts)
+		defer e.release()
+		truth, err := c.compiledFor(rValue.Type())(e, rValue)
+		if err != nil {
+			return false, e.stats, err
+		}
+		isValid, err = e.result(truth)
+		return isValid, e.stats, err
+	}
+	e, err := c.newEvaluation(ctx, data, opts)
+	if err != nil {
+		return false, stats, err
+	}
+	truth, err := c.validateAttribute(e)
+	if err != nil {
+		return false, e.stats, err
+	}
+	isValid, err = e.result(truth)
+	return isValid, e.stats, err
+}
 
-			switch value.(type) {
-			case int, int64:
-				value = interfaceToInt64(value)
-				conditionValue, err = strconv.ParseInt(c.Attribute.Value, 10, 64)
-			case *int, *int64:
-				value = interfacePtrToInt64(value)
-				conditionValue, err = strconv.ParseInt(c.Attribute.Value, 10, 64)
-			case float32, float64:
-				value = interfaceToFloat64(value)
-				conditionValue, err = strconv.ParseFloat(c.Attribute.Value, 64)
-			case *float32, *float64:
-				value = interfacePtrToFloat64(value)
-				conditionValue, err = strconv.ParseInt(c.Attribute.Value, 10, 64)
-			case time.Time:
-				validationType = TypeTime
-				conditionValue, err = time.Parse(DateTimeFormat, c.Attribute.Value)
-			case *time.Time:
-				validationType = TypeTime
-				res, ok := value.(*time.Time)
-				if ok {
-					value = *res
+func (c *Condition) validateAttribute(e *evaluation) (truth Truth, err error) {
+	if e.tracing {
+		defer e.traceCondition(c)(&truth, &err)
+	}
+	if len(c.Conditions) > 0 {
+		for i, subCondition := range c.Conditions {
+			if i > 0 && !e.exhaustive && truth.isDecided(subCondition.Operator) {
+				e.stats.LeavesShortCircuited += subCondition.countLeaves()
+				if e.tracing {
+					e.traceShortCircuit(subCondition)
 				}
-				conditionValue, err = time.Parse(DateTimeFormat, c.Attribute.Value)
-			case bool:
-				validationType = TypeAlphanumeric
-				conditionValue = stringToBool(c.Attribute.Value)
-			default:
-				validationType = TypeAlphanumeric
-				conditionValue = c.Attribute.Value
+				continue
 			}
+			subTruth, err := subCondition.validateAttribute(e)
 			if err != nil {
-				return false, err
+				return TruthFalse, err
 			}
-
-			switch operator {
-			case OperatorEqual:
-				isValid = value == conditionValue
-			default:
-				switch validationType {
-				case TypeTime:
-					isValid = validateTime(value, operator, conditionValue)
-				default:
-					isValid = validateNumeric(value, operator, conditionValue)
+			if i == 0 {
+				truth = subTruth
+			} else {
+				if subCondition.Operator == LogicalOperatorOr {
+					truth = truth.Or(subTruth)
+				} else {
+					truth = truth.And(subTruth)
 				}
 			}
 		}
+	} else {
+		truth, err = e.compareAttribute(c.Attribute)
 	}
 	return
 }
 
-func (c *Condition) validateMapValue(data map[string]interface{}) (isValid, isSkip bool, err error) {
-	isSkip = true
-	for key, value := range data {
-		if len(key) > 0 && !strings.HasPrefix(c.Attribute.Name, key) {
-			continue
-		}
-		isSkip = false
-		isValid, err = c.validateStructValue(key+".", value)
-		if err != nil {
-			return false, false, err
-		}
-		if !isValid {
-			break
-		}
+func (c *Condition) countLeaves() int {
+	if len(c.Conditions) == 0 {
+		return 1
 	}
-	return
+	count := 0
+	for _, subCondition := range c.Conditions {
+		count += subCondition.countLeaves()
+	}
+	return count
 }
 
 func validateTime(firstVal interface{}, operator string, secondVal interface{}) bool {
@@ -283,3 +307,18 @@ func validateNumeric(firstVal interface{}, operator string, secondVal interface{
 		return firstFloat <= secondFloat
 	}
 }
+
+func validateInteger(firstVal int64, operator string, secondVal int64) bool {
+	switch operator {
+	case OperatorEqual:
+		return firstVal == secondVal
+	case OperatorGreaterThan:
+		return firstVal > secondVal
+	case OperatorLessThan:
+		return firstVal < secondVal
+	case OperatorGreaterThanEqual:
+		return firstVal >= secondVal
+	default:
+		return firstVal <= secondVal
+	}
+}
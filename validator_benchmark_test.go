@@ -10,7 +10,9 @@ import (
 //------------------------------------
 //	attempt	   |  time per loop
 //------------------------------------
-//  484363	      2316 ns/op (now)
+//  484363	      2316 ns/op
+//  3653755	      333 ns/op
+//  5424723	      207 ns/op (now)
 //------------------------------------
 func BenchmarkValidate(b *testing.B) {
 	object := struct {
@@ -60,7 +62,8 @@ func BenchmarkValidateObjects(b *testing.B) {
 //------------------------------------
 //	attempt	   |  time per loop
 //------------------------------------
-//  172010	      7053 ns/op (now)
+//  172010	      7053 ns/op
+//  1315914	      1292 ns/op (now)
 //------------------------------------
 func BenchmarkValidateComplexOperator(b *testing.B) {
 	fInt := func(i int) *int {
@@ -104,3 +107,41 @@ func BenchmarkValidateComplexOperator(b *testing.B) {
 		condition.Validate(object)
 	}
 }
+
+//BENCHMARK FilterSlice
+//Improvement history:
+//------------------------------------
+//	attempt	   |  time per loop
+//------------------------------------
+//  542	      2218041 ns/op
+//  3606	      334271 ns/op
+//  3402	      310877 ns/op
+//  10000	      107652 ns/op (now)
+//------------------------------------
+func BenchmarkFilterSlice(b *testing.B) {
+	type Account struct {
+		ID       int     `json:"id"`
+		MemberID int     `json:"member_id"`
+		Division string  `json:"division"`
+		Score    int     `json:"score"`
+		Money    float64 `json:"money"`
+	}
+	divisions := []string{"engineering", "finance", "people", "business"}
+	accounts := make([]Account, 1000)
+	for i := range accounts {
+		accounts[i] = Account{
+			ID:       i,
+			MemberID: i % 50,
+			Division: divisions[i%len(divisions)],
+			Score:    i % 100,
+			Money:    float64(i * 1000),
+		}
+	}
+
+	query := "(division=engineering || division=finance) && score>=50 && money<500000"
+	condition, _ := GenerateCondition(query)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		condition.FilterSlice(accounts)
+	}
+}
@@ -145,6 +145,32 @@ func TestCondition_Validate(t *testing.T) {
 			wantIsValid: false,
 			wantErr:     true,
 		},
+		{
+			name: "Normal case - pointer to struct validation",
+			args: args{
+				query: `id=1 && (division=engineering || division=finance)`,
+				object: &struct {
+					ID       string `json:"id"`
+					Division string `json:"division"`
+				}{
+					ID:       "1",
+					Division: "finance",
+				},
+			},
+			wantIsValid: true,
+			wantErr:     false,
+		},
+		{
+			name: "Error case - nil pointer",
+			args: args{
+				query: `id=1`,
+				object: (*struct {
+					ID string `json:"id"`
+				})(nil),
+			},
+			wantIsValid: false,
+			wantErr:     true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -157,6 +183,20 @@ func TestCondition_Validate(t *testing.T) {
 			if gotIsValid != tt.wantIsValid {
 				t.Errorf("Condition.Validate() = %v, want %v", gotIsValid, tt.wantIsValid)
 			}
+
+			bytecode := condition.CompileBytecode()
+			gotIsValid, err = bytecode.Validate(tt.args.object)
+			if (err != nil) != tt.wantErr || gotIsValid != tt.wantIsValid {
+				t.Errorf("Bytecode.Validate() = %v, %v, want %v, wantErr %v", gotIsValid, err, tt.wantIsValid, tt.wantErr)
+			}
+			machine, err := bytecode.Bind(reflect.TypeOf(tt.args.object))
+			if err != nil {
+				return
+			}
+			gotIsValid, err = machine.Run(tt.args.object)
+			if (err != nil) != tt.wantErr || gotIsValid != tt.wantIsValid {
+				t.Errorf("Machine.Run() = %v, %v, want %v, wantErr %v", gotIsValid, err, tt.wantIsValid, tt.wantErr)
+			}
 		})
 	}
 }
@@ -550,6 +590,24 @@ func TestCondition_ValidateObjects(t *testing.T) {
 			wantIsValid: true,
 			wantErr:     false,
 		},
+		{
+			name: "Normal case - multi struct validation - pointers",
+			args: args{
+				query: `firstStruct.id=123 && secondStruct.name=Test`,
+				data: []interface{}{
+					&firstStruct{
+						ID:       "123",
+						MemberID: "345",
+						Division: "engineering",
+					},
+					&secondStruct{
+						Name: "Test",
+					},
+				},
+			},
+			wantIsValid: true,
+			wantErr:     false,
+		},
 		{
 			name: "Normal case - struct validation",
 			args: args{
@@ -615,3 +673,194 @@ func TestCondition_ValidateObjects(t *testing.T) {
 		})
 	}
 }
+
+func TestCondition_FilterSlicePointer(t *testing.T) {
+	type Account struct {
+		ID       int    `json:"id"`
+		Division string `json:"division"`
+	}
+	first := &Account{ID: 1, Division: "finance"}
+	second := &Account{ID: 2, Division: "engineering"}
+	third := &Account{ID: 3, Division: "finance"}
+
+	type args struct {
+		query   string
+		objects interface{}
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantResults interface{}
+		wantErr     bool
+	}{
+		{
+			name: "Normal case - slice of pointers",
+			args: args{
+				query:   "division=finance",
+				objects: []*Account{first, second, third},
+			},
+			wantResults: []*Account{first, third},
+			wantErr:     false,
+		},
+		{
+			name: "Normal case - pointer to slice",
+			args: args{
+				query:   "id>1",
+				objects: &[]Account{*first, *second, *third},
+			},
+			wantResults: []Account{*second, *third},
+			wantErr:     false,
+		},
+		{
+			name: "Error case - nil element",
+			args: args{
+				query:   "division=finance",
+				objects: []*Account{first, nil},
+			},
+			wantResults: nil,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _ := GenerateCondition(tt.args.query)
+			gotResults, err := condition.FilterSlice(tt.args.objects)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Condition.FilterSlice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(gotResults, tt.wantResults) {
+				t.Errorf("Condition.FilterSlice() = %v, want %v", gotResults, tt.wantResults)
+			}
+		})
+	}
+}
+
+func TestCondition_ValidateWithStats(t *testing.T) {
+	object := struct {
+		ID       int    `json:"id"`
+		MemberID int    `json:"member_id"`
+		Division string `json:"division"`
+	}{
+		ID:       1,
+		MemberID: 2,
+		Division: "finance",
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		wantIsValid bool
+		wantStats   EvaluationStats
+		wantErr     bool
+	}{
+		{
+			name:        "Normal case - and chain stops at first false",
+			query:       `id=2 && member_id=2 && division=finance`,
+			wantIsValid: false,
+			wantStats:   EvaluationStats{LeavesVisited: 1, LeavesShortCircuited: 2},
+		},
+		{
+			name:        "Normal case - or chain stops at first true",
+			query:       `id=1 || (member_id=3 && division=people) || division=finance`,
+			wantIsValid: true,
+			wantStats:   EvaluationStats{LeavesVisited: 1, LeavesShortCircuited: 3},
+		},
+		{
+			name:        "Normal case - left to right grouping is kept",
+			query:       `id=2 && member_id=2 || division=finance`,
+			wantIsValid: true,
+			wantStats:   EvaluationStats{LeavesVisited: 2, LeavesShortCircuited: 1},
+		},
+		{
+			name:        "Normal case - skipped leaf does not raise error",
+			query:       `id=1 || member_id>abc`,
+			wantIsValid: true,
+			wantStats:   EvaluationStats{LeavesVisited: 1, LeavesShortCircuited: 1},
+		},
+		{
+			name:        "Normal case - every leaf visited",
+			query:       `(id=1 && member_id=2) && (division=engineering || division=finance)`,
+			wantIsValid: true,
+			wantStats:   EvaluationStats{LeavesVisited: 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, _ := GenerateCondition(tt.query)
+			gotIsValid, gotStats, err := condition.ValidateWithStats(object)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Condition.ValidateWithStats() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotIsValid != tt.wantIsValid {
+				t.Errorf("Condition.ValidateWithStats() = %v, want %v", gotIsValid, tt.wantIsValid)
+			}
+			if gotStats != tt.wantStats {
+				t.Errorf("Condition.ValidateWithStats() stats = %+v, want %+v", gotStats, tt.wantStats)
+			}
+		})
+	}
+}
+
+func TestCondition_ValidateRelativeTime(t *testing.T) {
+	type Member struct {
+		JoinDate time.Time `json:"join_date"`
+		Since    string    `json:"since"`
+	}
+	now := time.Now()
+	member := Member{
+		JoinDate: now.Add(-48 * time.Hour),
+		Since:    now.Add(-30 * 24 * time.Hour).Format(DateTimeFormat),
+	}
+	document := map[string]interface{}{
+		"join_date": member.JoinDate,
+		"since":     member.Since,
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		wantIsValid bool
+		wantErr     bool
+	}{
+		{
+			name:        "Normal case - time field within relative window",
+			query:       `join_date > now - 7d && join_date < now-1d`,
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - time string before relative time",
+			query:       `since < now-1w && since >= now-5w && since <= now`,
+			wantIsValid: true,
+		},
+		{
+			name:        "Negative case - time field outside relative window",
+			query:       `join_date > now-1h || since > now+1h`,
+			wantIsValid: false,
+		},
+		{
+			name:    "Error case - invalid relative time unit",
+			query:   `join_date > now-7y`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := GenerateCondition(tt.query)
+			if err != nil {
+				t.Fatalf("GenerateCondition() error = %v", err)
+			}
+			for _, data := range []interface{}{member, document} {
+				gotIsValid, err := condition.Validate(data, WithMode(ModeStrict))
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Condition.Validate(%T) error = %v, wantErr %v", data, err, tt.wantErr)
+					continue
+				}
+				if gotIsValid != tt.wantIsValid {
+					t.Errorf("Condition.Validate(%T) = %v, want %v", data, gotIsValid, tt.wantIsValid)
+				}
+			}
+		})
+	}
+}
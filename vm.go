@@ -0,0 +1,472 @@
+package astvalidator
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+)
+
+type Opcode byte
+
+const (
+	OpLoadField Opcode = iota + 1
+	OpPushConst
+	OpCompare
+	OpPushFalse
+	OpJumpIfFalse
+	OpJumpIfTrue
+	OpAnd
+	OpOr
+)
+
+const (
+	bytecodeMagic   = "ASTV"
+	bytecodeVersion = 1
+	vmStackSize     = 16
+)
+
+type Instruction struct {
+	Op      Opcode `json:"op"`
+	Operand int    `json:"operand,omitempty"`
+}
+
+type Bytecode struct {
+	Instructions []Instruction `json:"instructions"`
+	Attributes   []Attribute   `json:"attributes"`
+	MaxDepth     int           `json:"max_depth"`
+}
+
+type Machine struct {
+	bytecode *Bytecode
+	rType    reflect.Type
+	leaves   []machineLeaf
+}
+
+type vmSlot struct {
+	kind    vmSlotKind
+	truth   Truth
+	field   reflect.Value
+	operand int
+}
+
+type vmSlotKind byte
+
+const (
+	slotTruth vmSlotKind = iota
+	slotField
+	slotConst
+)
+
+type machineLeaf struct {
+	index   []int
+	match   matcher
+	problem error
+	isBound bool
+}
+
+var mapOpcodeName = map[Opcode]string{
+	OpLoadField:   "LOAD_FIELD",
+	OpPushConst:   "PUSH_CONST",
+	OpCompare:     "COMPARE",
+	OpPushFalse:   "PUSH_FALSE",
+	OpJumpIfFalse: "JUMP_IF_FALSE",
+	OpJumpIfTrue:  "JUMP_IF_TRUE",
+	OpAnd:         "AND",
+	OpOr:          "OR",
+}
+
+func WithStepBudget(steps int) Option {
+	return func(e *evaluation) {
+		e.stepBudget = steps
+	}
+}
+
+func (c *Condition) CompileBytecode() *Bytecode {
+	b := &Bytecode{}
+	b.emit(c)
+	b.MaxDepth, _ = b.stackDepth()
+	return b
+}
+
+func (b *Bytecode) emit(c *Condition) {
+	if len(c.Conditions) == 0 {
+		if c.Attribute == nil {
+			b.Instructions = append(b.Instructions, Instruction{Op: OpPushFalse})
+			return
+		}
+		index := len(b.Attributes)
+		b.Attributes = append(b.Attributes, *c.Attribute)
+		b.Instructions = append(b.Instructions,
+			Instruction{Op: OpLoadField, Operand: index},
+			Instruction{Op: OpPushConst, Operand: index},
+			Instruction{Op: OpCompare, Operand: index},
+		)
+		return
+	}
+	b.emit(c.Conditions[0])
+	for _, subCondition := range c.Conditions[1:] {
+		jump, combine := OpJumpIfFalse, OpAnd
+		if subCondition.Operator == LogicalOperatorOr {
+			jump, combine = OpJumpIfTrue, OpOr
+		}
+		position := len(b.Instructions)
+		b.Instructions = append(b.Instructions, Instruction{Op: jump})
+		b.emit(subCondition)
+		b.Instructions = append(b.Instructions, Instruction{Op: combine})
+		b.Instructions[position].Operand = len(b.Instructions)
+	}
+}
+
+func (b *Bytecode) Bind(rType reflect.Type) (*Machine, error) {
+	if rType == nil {
+		return nil, ErrNilData
+	}
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	if rType.Kind() != reflect.Struct {
+		return nil, newUnsupportedTypeError("struct", rType)
+	}
+	if err := b.verify(); err != nil {
+		return nil, err
+	}
+	plan := getStructPlan(rType)
+	machine := &Machine{
+		bytecode: b,
+		rType:    rType,
+		leaves:   make([]machineLeaf, len(b.Attributes)),
+	}
+	for i := range b.Attributes {
+		attribute := &b.Attributes[i]
+		field, ok := plan.fields[attribute.Name]
+		switch {
+		case !ok, field.compare == nil, attribute.Operator == OperatorIsMissing, attribute.Operator == OperatorIsNotMissing:
+			continue
+		}
+		match, problem := compileMatcher(field.rType, attribute)
+		machine.leaves[i] = machineLeaf{
+			index:   field.index,
+			match:   match,
+			problem: problem,
+			isBound: true,
+		}
+	}
+	return machine, nil
+}
+
+func (b *Bytecode) Validate(data interface{}, opts ...Option) (bool, error) {
+	return b.ValidateContext(context.Background(), data, opts...)
+}
+
+func (b *Bytecode) ValidateContext(ctx context.Context, data interface{}, opts ...Option) (bool, error) {
+	e, err := (&Condition{}).newEvaluation(ctx, data, opts)
+	if err != nil {
+		return false, err
+	}
+	truth, err := b.run(e, reflect.Value{}, nil)
+	if err != nil {
+		return false, err
+	}
+	return e.result(truth)
+}
+
+func (m *Machine) Run(data interface{}, opts ...Option) (bool, error) {
+	return m.RunContext(context.Background(), data, opts...)
+}
+
+func (m *Machine) RunContext(ctx context.Context, data interface{}, opts ...Option) (bool, error) {
+	if data == nil {
+		return false, ErrNilData
+	}
+	rValue := reflect.ValueOf(data)
+	for rValue.Kind() == reflect.Ptr {
+		if rValue.IsNil() {
+			return false, ErrNilPointer
+		}
+		rValue = rValue.Elem()
+	}
+	if rValue.Type() != m.rType {
+		return false, newUnsupportedTypeError(m.rType.String(), rValue.Type())
+	}
+	e := (&Condition{}).acquireEvaluation(ctx, rValue, opts)
+	defer e.release()
+	truth, err := m.bytecode.run(e, rValue, m.leaves)
+	if err != nil {
+		return false, err
+	}
+	return e.result(truth)
+}
+
+func (b *Bytecode) run(e *evaluation, rValue reflect.Value, leaves []machineLeaf) (Truth, error) {
+	var (
+		buffer [vmStackSize]vmSlot
+		stack  = buffer[:0]
+		steps  int
+	)
+	if b.MaxDepth > vmStackSize {
+		stack = make([]vmSlot, 0, b.MaxDepth)
+	}
+	for pc := 0; pc < len(b.Instructions); pc++ {
+		steps++
+		if e.stepBudget > 0 && steps > e.stepBudget {
+			return TruthFalse, ErrStepBudgetExceeded
+		}
+		instruction := &b.Instructions[pc]
+		switch instruction.Op {
+		case OpLoadField:
+			slot := vmSlot{kind: slotField, operand: instruction.Operand}
+			if leaves != nil && leaves[instruction.Operand].isBound {
+				slot.field = fieldByIndex(rValue, leaves[instruction.Operand].index)
+			}
+			stack = append(stack, slot)
+		case OpPushConst:
+			stack = append(stack, vmSlot{kind: slotConst, operand: instruction.Operand})
+		case OpCompare:
+			if len(stack) < 2 || !stack[len(stack)-2].isOperand(slotField, instruction.Operand) || !stack[len(stack)-1].isOperand(slotConst, instruction.Operand) {
+				return TruthFalse, ErrInvalidBytecode
+			}
+			truth, err := b.compare(e, stack[len(stack)-2].field, instruction.Operand, leaves)
+			if err != nil {
+				return TruthFalse, err
+			}
+			stack = append(stack[:len(stack)-2], vmSlot{truth: truth})
+		case OpPushFalse:
+			stack = append(stack, vmSlot{truth: TruthFalse})
+		case OpJumpIfFalse, OpJumpIfTrue:
+			if len(stack) == 0 || stack[len(stack)-1].kind != slotTruth {
+				return TruthFalse, ErrInvalidBytecode
+			}
+			top := stack[len(stack)-1].truth
+			if (instruction.Op == OpJumpIfFalse && top == TruthFalse) || (instruction.Op == OpJumpIfTrue && top == TruthTrue) {
+				pc = instruction.Operand - 1
+			}
+		case OpAnd, OpOr:
+			if len(stack) < 2 || stack[len(stack)-2].kind != slotTruth || stack[len(stack)-1].kind != slotTruth {
+				return TruthFalse, ErrInvalidBytecode
+			}
+			left, right := stack[len(stack)-2].truth, stack[len(stack)-1].truth
+			stack = stack[:len(stack)-1]
+			if instruction.Op == OpAnd {
+				stack[len(stack)-1].truth = left.And(right)
+			} else {
+				stack[len(stack)-1].truth = left.Or(right)
+			}
+		default:
+			return TruthFalse, ErrInvalidBytecode
+		}
+	}
+	if len(stack) != 1 || stack[0].kind != slotTruth {
+		return TruthFalse, ErrInvalidBytecode
+	}
+	return stack[0].truth, nil
+}
+
+func (s vmSlot) isOperand(kind vmSlotKind, operand int) bool {
+	return s.kind == kind && s.operand == operand
+}
+
+func (b *Bytecode) compare(e *evaluation, field reflect.Value, index int, leaves []machineLeaf) (Truth, error) {
+	if leaves == nil || !leaves[index].isBound {
+		return e.compareAttribute(&b.Attributes[index])
+	}
+	if err := e.ctx.Err(); err != nil {
+		return TruthFalse, err
+	}
+	e.stats.LeavesVisited++
+	leaf := &leaves[index]
+	if leaf.match == nil {
+		return e.truth(false, leaf.problem)
+	}
+	return e.truth(leaf.match(field))
+}
+
+func fieldByIndex(rValue reflect.Value, index []int) reflect.Value {
+	if len(index) == 1 {
+		return rValue.Field(index[0])
+	}
+	return rValue.FieldByIndex(index)
+}
+
+func (b *Bytecode) MarshalBinary() ([]byte, error) {
+	data := append([]byte(bytecodeMagic), bytecodeVersion)
+	data = binary.AppendUvarint(data, uint64(b.MaxDepth))
+	data = binary.AppendUvarint(data, uint64(len(b.Instructions)))
+	for _, instruction := range b.Instructions {
+		data = append(data, byte(instruction.Op))
+		data = binary.AppendUvarint(data, uint64(instruction.Operand))
+	}
+	data = binary.AppendUvarint(data, uint64(len(b.Attributes)))
+	for _, attribute := range b.Attributes {
+		for _, value := range []string{attribute.Name, attribute.Operator, attribute.Value, attribute.Code, attribute.Message} {
+			data = binary.AppendUvarint(data, uint64(len(value)))
+			data = append(data, value...)
+		}
+	}
+	return data, nil
+}
+
+func (b *Bytecode) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, []byte(bytecodeMagic)) || len(data) < len(bytecodeMagic)+1 || data[len(bytecodeMagic)] != bytecodeVersion {
+		return ErrInvalidBytecode
+	}
+	reader := bytes.NewReader(data[len(bytecodeMagic)+1:])
+	readInt := func() (int, error) {
+		value, err := binary.ReadUvarint(reader)
+		if err != nil || value > uint64(len(data)) {
+			return 0, ErrInvalidBytecode
+		}
+		return int(value), nil
+	}
+	readString := func() (string, error) {
+		size, err := readInt()
+		if err != nil || size > reader.Len() {
+			return "", ErrInvalidBytecode
+		}
+		value := make([]byte, size)
+		reader.Read(value)
+		return string(value), nil
+	}
+
+	decoded := Bytecode{}
+	var err error
+	if decoded.MaxDepth, err = readInt(); err != nil {
+		return err
+	}
+	size, err := readInt()
+	if err != nil {
+		return err
+	}
+	decoded.Instructions = make([]Instruction, size)
+	for i := range decoded.Instructions {
+		op, err := reader.ReadByte()
+		if err != nil {
+			return ErrInvalidBytecode
+		}
+		decoded.Instructions[i].Op = Opcode(op)
+		if decoded.Instructions[i].Operand, err = readInt(); err != nil {
+			return err
+		}
+	}
+	if size, err = readInt(); err != nil {
+		return err
+	}
+	decoded.Attributes = make([]Attribute, size)
+	for i := range decoded.Attributes {
+		attribute := &decoded.Attributes[i]
+		for _, value := range []*string{&attribute.Name, &attribute.Operator, &attribute.Value, &attribute.Code, &attribute.Message} {
+			if *value, err = readString(); err != nil {
+				return err
+			}
+		}
+	}
+	if reader.Len() > 0 {
+		return ErrInvalidBytecode
+	}
+	if err := decoded.verify(); err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+func (b *Bytecode) verify() error {
+	depth, err := b.stackDepth()
+	if err != nil {
+		return err
+	}
+	if b.MaxDepth < depth || b.MaxDepth > len(b.Instructions) {
+		return ErrInvalidBytecode
+	}
+	return nil
+}
+
+func (b *Bytecode) stackDepth() (int, error) {
+	var (
+		states   = make([][]vmSlot, len(b.Instructions)+1)
+		maxDepth int
+	)
+	states[0] = []vmSlot{}
+	merge := func(pc int, stack []vmSlot) bool {
+		if states[pc] == nil {
+			states[pc] = stack
+			return true
+		}
+		return reflect.DeepEqual(states[pc], stack)
+	}
+	for pc, instruction := range b.Instructions {
+		stack := states[pc]
+		if stack == nil {
+			return 0, ErrInvalidBytecode
+		}
+		stack = append([]vmSlot{}, stack...)
+		switch instruction.Op {
+		case OpLoadField, OpPushConst, OpCompare:
+			if instruction.Operand >= len(b.Attributes) {
+				return 0, ErrInvalidBytecode
+			}
+		}
+		switch instruction.Op {
+		case OpLoadField:
+			stack = append(stack, vmSlot{kind: slotField, operand: instruction.Operand})
+		case OpPushConst:
+			stack = append(stack, vmSlot{kind: slotConst, operand: instruction.Operand})
+		case OpCompare:
+			if len(stack) < 2 || !stack[len(stack)-2].isOperand(slotField, instruction.Operand) || !stack[len(stack)-1].isOperand(slotConst, instruction.Operand) {
+				return 0, ErrInvalidBytecode
+			}
+			stack = append(stack[:len(stack)-2], vmSlot{kind: slotTruth})
+		case OpPushFalse:
+			stack = append(stack, vmSlot{kind: slotTruth})
+		case OpJumpIfFalse, OpJumpIfTrue:
+			if instruction.Operand <= pc || instruction.Operand > len(b.Instructions) {
+				return 0, ErrInvalidBytecode
+			}
+			if len(stack) == 0 || stack[len(stack)-1].kind != slotTruth || !merge(instruction.Operand, stack) {
+				return 0, ErrInvalidBytecode
+			}
+		case OpAnd, OpOr:
+			if len(stack) < 2 || stack[len(stack)-2].kind != slotTruth || stack[len(stack)-1].kind != slotTruth {
+				return 0, ErrInvalidBytecode
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			return 0, ErrInvalidBytecode
+		}
+		maxDepth = max(maxDepth, len(stack))
+		if !merge(pc+1, stack) {
+			return 0, ErrInvalidBytecode
+		}
+	}
+	if final := states[len(b.Instructions)]; len(final) != 1 || final[0].kind != slotTruth {
+		return 0, ErrInvalidBytecode
+	}
+	return maxDepth, nil
+}
+
+func (b *Bytecode) String() string {
+	buffer := &bytes.Buffer{}
+	for pc, instruction := range b.Instructions {
+		fmt.Fprintf(buffer, "%04d %s", pc, instruction.Op)
+		switch instruction.Op {
+		case OpLoadField:
+			fmt.Fprintf(buffer, " %s", b.Attributes[instruction.Operand].Name)
+		case OpPushConst:
+			fmt.Fprintf(buffer, " %q", b.Attributes[instruction.Operand].Value)
+		case OpCompare:
+			fmt.Fprintf(buffer, " %s", b.Attributes[instruction.Operand].Operator)
+		case OpJumpIfFalse, OpJumpIfTrue:
+			fmt.Fprintf(buffer, " %04d", instruction.Operand)
+		}
+		buffer.WriteByte('\n')
+	}
+	return buffer.String()
+}
+
+func (op Opcode) String() string {
+	if name, ok := mapOpcodeName[op]; ok {
+		return name
+	}
+	return fmt.Sprintf("OP_%d", byte(op))
+}
@@ -0,0 +1,33 @@
+package astvalidator
+
+import (
+	"reflect"
+	"testing"
+)
+
+//BENCHMARK Machine Run
+//Improvement history:
+//------------------------------------
+//	attempt	   |  time per loop
+//------------------------------------
+//  5524737	      220 ns/op (now)
+//------------------------------------
+func BenchmarkMachineRun(b *testing.B) {
+	type Object struct {
+		ID       string `json:"id"`
+		MemberID string `json:"member_id"`
+		Division string `json:"division"`
+	}
+	object := Object{
+		ID:       "1",
+		MemberID: "2",
+		Division: "finance",
+	}
+
+	query := "(id=1 && (member_id=12||member_id=2))  &&   (division=engineering || division=finance)"
+	condition, _ := GenerateCondition(query)
+	machine, _ := condition.CompileBytecode().Bind(reflect.TypeOf(object))
+	for n := 0; n < b.N; n++ {
+		machine.Run(&object)
+	}
+}
@@ -0,0 +1,223 @@
+package astvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBytecode_Equivalence(t *testing.T) {
+	type Profile struct {
+		City string `json:"city"`
+	}
+	type Member struct {
+		ID       int       `json:"id"`
+		MemberID string    `json:"member_id"`
+		Division string    `json:"division"`
+		Score    float64   `json:"score"`
+		Active   bool      `json:"active"`
+		JoinDate time.Time `json:"join_date"`
+		Email    *string   `json:"email"`
+		Profile  Profile   `json:"profile"`
+	}
+	members := []Member{
+		{ID: 1, MemberID: "3", Division: "finance", Score: 75, Active: true, JoinDate: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC), Profile: Profile{City: "jakarta"}},
+		{ID: 2, MemberID: "12", Division: "engineering", Score: 90, JoinDate: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	documents := []map[string]interface{}{
+		{"id": 1, "member_id": "3", "division": "finance", "score": 75.0, "profile": map[string]interface{}{"city": "jakarta"}},
+		{"id": 2, "division": "engineering", "email": nil},
+	}
+
+	queries := []string{
+		``,
+		`id=1`,
+		`(id=1 && (member_id=12||member_id=2)) && (division=engineering || division=finance)`,
+		`id=1 && member_id=3 || division=engineering && score>80`,
+		`((id=1 || id=2) && (division=people || (score>=75 && active=true))) || brand=nike`,
+		`join_date>"2015-01-01 00:00:00" && profile.city=jakarta`,
+		`email is missing && email=x || score<=75`,
+		`brand=nike || id=2`,
+		`score>abc || id=1`,
+		`member_id>10 && division=engineering`,
+	}
+	for _, query := range queries {
+		condition, _ := GenerateCondition(query)
+		bytecode := condition.CompileBytecode()
+		machine, err := bytecode.Bind(reflect.TypeOf(Member{}))
+		if err != nil {
+			t.Fatalf("Bytecode.Bind(%q) error = %v", query, err)
+		}
+		for _, mode := range []Mode{ModeLenient, ModeStrict} {
+			for _, member := range members {
+				wantIsValid, _, wantErr := condition.ValidateWithTrace(member, WithMode(mode))
+				gotIsValid, gotErr := machine.Run(member, WithMode(mode))
+				if gotIsValid != wantIsValid || (gotErr != nil) != (wantErr != nil) {
+					t.Errorf("Machine.Run(%q, %v) = %v, %v, want %v, %v", query, mode, gotIsValid, gotErr, wantIsValid, wantErr)
+				}
+			}
+			for _, document := range documents {
+				wantIsValid, _, wantErr := condition.ValidateWithTrace(document, WithMode(mode))
+				gotIsValid, gotErr := bytecode.Validate(document, WithMode(mode))
+				if gotIsValid != wantIsValid || (gotErr != nil) != (wantErr != nil) {
+					t.Errorf("Bytecode.Validate(%q, %v) = %v, %v, want %v, %v", query, mode, gotIsValid, gotErr, wantIsValid, wantErr)
+				}
+			}
+		}
+	}
+}
+
+func TestBytecode_MarshalBinary(t *testing.T) {
+	condition, _ := GenerateCondition(`(id=1 : "id must be 1" || division=finance) && score>=75`)
+	bytecode := condition.CompileBytecode()
+	data, err := bytecode.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Bytecode.MarshalBinary() error = %v", err)
+	}
+	decoded := &Bytecode{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Bytecode.UnmarshalBinary() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, bytecode) {
+		t.Errorf("Bytecode.UnmarshalBinary() = %v, want %v", decoded, bytecode)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "Error case - empty", data: nil},
+		{name: "Error case - bad magic", data: append([]byte("XXXX"), data[4:]...)},
+		{name: "Error case - truncated", data: data[:len(data)-3]},
+		{name: "Error case - trailing bytes", data: append(append([]byte{}, data...), 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := (&Bytecode{}).UnmarshalBinary(tt.data); !errors.Is(err, ErrInvalidBytecode) {
+				t.Errorf("Bytecode.UnmarshalBinary() error = %v, want %v", err, ErrInvalidBytecode)
+			}
+		})
+	}
+
+	attributes := []Attribute{{Name: "id", Operator: "=", Value: "1"}}
+	invalidTests := []struct {
+		name     string
+		bytecode *Bytecode
+	}{
+		{
+			name:     "Error case - backward jump",
+			bytecode: &Bytecode{Instructions: []Instruction{{Op: OpJumpIfTrue, Operand: 0}}, MaxDepth: 1},
+		},
+		{
+			name:     "Error case - unbalanced stack",
+			bytecode: &Bytecode{Instructions: []Instruction{{Op: OpPushFalse}, {Op: OpPushFalse}}, MaxDepth: 2},
+		},
+		{
+			name:     "Error case - stack underflow",
+			bytecode: &Bytecode{Instructions: []Instruction{{Op: OpPushFalse}, {Op: OpAnd}}, MaxDepth: 1},
+		},
+		{
+			name: "Error case - compare without field",
+			bytecode: &Bytecode{
+				Instructions: []Instruction{{Op: OpPushConst}, {Op: OpPushConst}, {Op: OpCompare}},
+				Attributes:   attributes,
+				MaxDepth:     2,
+			},
+		},
+		{
+			name: "Error case - jump target with another stack",
+			bytecode: &Bytecode{
+				Instructions: []Instruction{{Op: OpPushFalse}, {Op: OpJumpIfFalse, Operand: 4}, {Op: OpPushFalse}, {Op: OpPushFalse}, {Op: OpOr}},
+				MaxDepth:     3,
+			},
+		},
+		{
+			name: "Error case - max depth too small",
+			bytecode: &Bytecode{
+				Instructions: []Instruction{{Op: OpLoadField}, {Op: OpPushConst}, {Op: OpCompare}},
+				Attributes:   attributes,
+				MaxDepth:     1,
+			},
+		},
+	}
+	for _, tt := range invalidTests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := tt.bytecode.MarshalBinary()
+			if err := (&Bytecode{}).UnmarshalBinary(data); !errors.Is(err, ErrInvalidBytecode) {
+				t.Errorf("Bytecode.UnmarshalBinary() error = %v, want %v", err, ErrInvalidBytecode)
+			}
+			if _, err := tt.bytecode.Bind(reflect.TypeOf(struct{ ID int }{})); !errors.Is(err, ErrInvalidBytecode) {
+				t.Errorf("Bytecode.Bind() error = %v, want %v", err, ErrInvalidBytecode)
+			}
+		})
+	}
+}
+
+func TestMachine_Run(t *testing.T) {
+	type Account struct {
+		ID       int    `json:"id"`
+		Division string `json:"division"`
+	}
+	condition, _ := GenerateCondition(`id=2 || id=3 || id=4 || division=finance`)
+	machine, _ := condition.CompileBytecode().Bind(reflect.TypeOf(Account{}))
+
+	tests := []struct {
+		name        string
+		data        interface{}
+		opts        []Option
+		wantIsValid bool
+		wantErr     error
+	}{
+		{
+			name:        "Normal case - valid",
+			data:        Account{ID: 1, Division: "finance"},
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - pointer",
+			data:        &Account{ID: 3},
+			wantIsValid: true,
+		},
+		{
+			name:        "Normal case - within budget",
+			data:        Account{ID: 2},
+			opts:        []Option{WithStepBudget(6)},
+			wantIsValid: true,
+		},
+		{
+			name:    "Error case - budget exceeded",
+			data:    Account{ID: 1, Division: "finance"},
+			opts:    []Option{WithStepBudget(6)},
+			wantErr: ErrStepBudgetExceeded,
+		},
+		{
+			name:    "Error case - another type",
+			data:    struct{ ID int }{ID: 2},
+			wantErr: ErrUnsupportedType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotIsValid, err := machine.Run(tt.data, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Machine.Run() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotIsValid != tt.wantIsValid {
+				t.Errorf("Machine.Run() = %v, want %v", gotIsValid, tt.wantIsValid)
+			}
+		})
+	}
+
+	if raceEnabled {
+		return
+	}
+	account := Account{ID: 5, Division: "finance"}
+	allocs := testing.AllocsPerRun(100, func() {
+		machine.Run(&account)
+	})
+	if allocs > 0 {
+		t.Errorf("Machine.Run() allocs = %v, want 0", allocs)
+	}
+}